@@ -0,0 +1,145 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AtomicUpdate - a partial update of a document, for more information visit https://lucene.apache.org/solr/guide/7_4/updating-parts-of-documents.html
+type AtomicUpdate struct {
+	id      string
+	version int64
+	fields  map[string]map[string]interface{}
+}
+
+// NewAtomicUpdate - creates a partial update of the document with the id
+func NewAtomicUpdate(id string) *AtomicUpdate {
+
+	return &AtomicUpdate{
+		id:     id,
+		fields: map[string]map[string]interface{}{},
+	}
+
+}
+
+// ID - the id of the updated document
+func (u *AtomicUpdate) ID() string {
+
+	return u.id
+
+}
+
+// ExpectVersion - applies the update only if the document _version_ matches, see VersionMustExist and VersionMustNotExist
+func (u *AtomicUpdate) ExpectVersion(version int64) *AtomicUpdate {
+
+	u.version = version
+
+	return u
+
+}
+
+// Set - replaces the field value, a nil value removes the field
+func (u *AtomicUpdate) Set(field string, value interface{}) *AtomicUpdate {
+
+	return u.operation(field, atomicSet, value)
+
+}
+
+// Add - adds the values to a multivalued field
+func (u *AtomicUpdate) Add(field string, value interface{}) *AtomicUpdate {
+
+	return u.operation(field, atomicAdd, value)
+
+}
+
+// AddDistinct - adds the values not already present to a multivalued field
+func (u *AtomicUpdate) AddDistinct(field string, value interface{}) *AtomicUpdate {
+
+	return u.operation(field, atomicAddDistinct, value)
+
+}
+
+// Remove - removes all occurrences of the values from a multivalued field
+func (u *AtomicUpdate) Remove(field string, value interface{}) *AtomicUpdate {
+
+	return u.operation(field, atomicRemove, value)
+
+}
+
+// RemoveRegex - removes all occurrences of the values matching the regular expressions from a multivalued field
+func (u *AtomicUpdate) RemoveRegex(field string, regex interface{}) *AtomicUpdate {
+
+	return u.operation(field, atomicRemoveRegex, regex)
+
+}
+
+// Inc - increments a numeric field by the value
+func (u *AtomicUpdate) Inc(field string, value interface{}) *AtomicUpdate {
+
+	return u.operation(field, atomicInc, value)
+
+}
+
+func (u *AtomicUpdate) operation(field, operation string, value interface{}) *AtomicUpdate {
+
+	operations, ok := u.fields[field]
+	if !ok {
+		operations = map[string]interface{}{}
+		u.fields[field] = operations
+	}
+
+	operations[operation] = value
+
+	return u
+
+}
+
+// MarshalJSON - renders the update as {"id":"1","field":{"set":"value"}}
+func (u *AtomicUpdate) MarshalJSON() ([]byte, error) {
+
+	if u.id == "" {
+		return nil, fmt.Errorf("atomic update id cannot be empty")
+	}
+
+	document := make(map[string]interface{}, len(u.fields)+2)
+
+	for field, operations := range u.fields {
+		document[field] = operations
+	}
+
+	document[rawID] = u.id
+
+	if u.version != VersionAny {
+		document[rawVersion] = u.version
+	}
+
+	return json.Marshal(document)
+
+}
+
+// UpdateAtomic - sends many partial updates in a single request, it does not use the DocumentWriter
+func (s *Instance) UpdateAtomic(instanceName string, postParams map[string]string, updates ...*AtomicUpdate) error {
+
+	return s.UpdateAtomicWithOptions(instanceName, postParams, nil, updates...)
+
+}
+
+// UpdateAtomicWithOptions - same as UpdateAtomic using the commit options of this call, nil uses the Instance commit options
+func (s *Instance) UpdateAtomicWithOptions(instanceName string, postParams map[string]string, commit *CommitOptions, updates ...*AtomicUpdate) error {
+
+	if len(updates) == 0 {
+		return fmt.Errorf("at least one update must be defined")
+	}
+
+	if commit == nil {
+		commit = s.commitOptions
+	}
+
+	payload, err := json.Marshal(updates)
+	if err != nil {
+		return err
+	}
+
+	return s.postUpdate(s.updateURL(instanceName, stringUpdate, postParams, commit), contentType, payload)
+
+}
@@ -0,0 +1,308 @@
+package solr
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BulkIndexerConfig - configuration of the BulkIndexer, a buffer is flushed when any of the thresholds is reached
+type BulkIndexerConfig struct {
+	Workers       int                    //Workers - number of concurrent flushes, default 1
+	MaxDocuments  int                    //MaxDocuments - flushes a collection buffer with this number of documents, default 1000
+	MaxBytes      int                    //MaxBytes - flushes a collection buffer with this size, measured with the DocumentWriter, zero disables it
+	FlushInterval time.Duration          //FlushInterval - flushes all buffers periodically, zero disables it
+	BufferSize    int                    //BufferSize - maximum number of documents not yet flushed, Add blocks when it is reached, default 10 * MaxDocuments
+	Commit        *CommitOptions         //Commit - commit options of each flush, nil uses the Instance commit options
+	OnFlush       func(*BulkFlushResult) //OnFlush - called after each successful flush, from the worker goroutines
+	OnFailure     func(*BulkFlushResult) //OnFailure - called after each failed flush, from the worker goroutines
+	Tolerant      *TolerantOptions       //Tolerant - flushes with UpdateDocumentTolerant, so only the invalid documents fail, its nil Commit uses the Commit above
+	DeadLetter    DeadLetterSink         //DeadLetter - receives the documents of the failed flushes, only the rejected ones on partial failures
+}
+
+// BulkFlushResult - the result of a flush
+type BulkFlushResult struct {
+	Collection    string
+	Documents     []interface{}
+	Bytes         int
+	Duration      time.Duration
+	Err           error
+	DeadLetters   int   //DeadLetters - the number of documents written to the dead letter sink
+	DeadLetterErr error //DeadLetterErr - the error writing to the dead letter sink
+}
+
+// BulkIndexer - buffers documents by collection and sends them in batches with UpdateDocument, it is safe for concurrent use
+type BulkIndexer struct {
+	instance *Instance
+	config   BulkIndexerConfig
+	buffers  map[string]*bulkBuffer
+	batches  chan *bulkBuffer
+	slots    chan struct{}
+	closed   bool
+	lock     sync.Mutex
+	pending  sync.WaitGroup
+	workers  sync.WaitGroup
+	stop     chan struct{}
+}
+
+type bulkBuffer struct {
+	collection string
+	documents  []interface{}
+	bytes      int
+}
+
+// NewBulkIndexer - creates a BulkIndexer and starts its workers
+func (s *Instance) NewBulkIndexer(config BulkIndexerConfig) (*BulkIndexer, error) {
+
+	if config.Workers < 0 || config.MaxDocuments < 0 || config.MaxBytes < 0 || config.BufferSize < 0 || config.FlushInterval < 0 {
+		return nil, fmt.Errorf("bulk indexer config cannot have negative values")
+	}
+
+	if config.Workers == 0 {
+		config.Workers = 1
+	}
+
+	if config.MaxDocuments == 0 {
+		config.MaxDocuments = 1000
+	}
+
+	if config.BufferSize == 0 {
+		config.BufferSize = 10 * config.MaxDocuments
+	}
+
+	if config.BufferSize < config.MaxDocuments {
+		return nil, fmt.Errorf("BufferSize cannot be smaller than MaxDocuments")
+	}
+
+	if config.Tolerant != nil && config.Tolerant.Commit == nil {
+		tolerant := *config.Tolerant
+		tolerant.Commit = config.Commit
+		config.Tolerant = &tolerant
+	}
+
+	b := &BulkIndexer{
+		instance: s,
+		config:   config,
+		buffers:  map[string]*bulkBuffer{},
+		batches:  make(chan *bulkBuffer, config.Workers),
+		slots:    make(chan struct{}, config.BufferSize),
+		stop:     make(chan struct{}),
+	}
+
+	b.workers.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go b.work()
+	}
+
+	if config.FlushInterval > 0 {
+		go b.tick()
+	}
+
+	return b, nil
+
+}
+
+// Add - buffers a document to be sent to the collection, it blocks while the buffer is full
+func (b *BulkIndexer) Add(collection string, document interface{}) error {
+
+	var size int
+
+	if b.config.MaxBytes > 0 {
+
+		raw, err := b.instance.documentWriter.Writer(document)
+		if err != nil {
+			return err
+		}
+
+		size = len(raw)
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+	default:
+		// the buffer is full, flushes the collections below the thresholds before waiting
+		b.Flush()
+		b.slots <- struct{}{}
+	}
+
+	b.lock.Lock()
+
+	if b.closed {
+		b.lock.Unlock()
+		<-b.slots
+		return fmt.Errorf("bulk indexer is closed")
+	}
+
+	buffer, ok := b.buffers[collection]
+	if !ok {
+		buffer = &bulkBuffer{collection: collection}
+		b.buffers[collection] = buffer
+	}
+
+	buffer.documents = append(buffer.documents, document)
+	buffer.bytes += size
+
+	var batch *bulkBuffer
+	if len(buffer.documents) >= b.config.MaxDocuments || (b.config.MaxBytes > 0 && buffer.bytes >= b.config.MaxBytes) {
+		batch = b.cut(collection)
+	}
+
+	b.lock.Unlock()
+
+	if batch != nil {
+		b.send(batch)
+	}
+
+	return nil
+
+}
+
+// Flush - sends all the buffered documents without waiting for the results
+func (b *BulkIndexer) Flush() {
+
+	b.lock.Lock()
+	batches := b.cutAll()
+	b.lock.Unlock()
+
+	for _, batch := range batches {
+		b.send(batch)
+	}
+
+}
+
+// Close - stops accepting documents, flushes the buffers and waits for all flushes to finish
+func (b *BulkIndexer) Close() error {
+
+	b.lock.Lock()
+
+	if b.closed {
+		b.lock.Unlock()
+		return fmt.Errorf("bulk indexer is already closed")
+	}
+
+	b.closed = true
+	batches := b.cutAll()
+
+	b.lock.Unlock()
+
+	close(b.stop)
+
+	for _, batch := range batches {
+		b.send(batch)
+	}
+
+	b.pending.Wait()
+	close(b.batches)
+	b.workers.Wait()
+
+	return nil
+
+}
+
+// cut - removes the buffer of the collection, must be called holding the lock
+func (b *BulkIndexer) cut(collection string) *bulkBuffer {
+
+	batch := b.buffers[collection]
+	delete(b.buffers, collection)
+	b.pending.Add(1)
+
+	return batch
+
+}
+
+// cutAll - removes all the non empty buffers, must be called holding the lock
+func (b *BulkIndexer) cutAll() []*bulkBuffer {
+
+	batches := make([]*bulkBuffer, 0, len(b.buffers))
+
+	for collection, buffer := range b.buffers {
+		if len(buffer.documents) > 0 {
+			batches = append(batches, b.cut(collection))
+		}
+	}
+
+	return batches
+
+}
+
+func (b *BulkIndexer) send(batch *bulkBuffer) {
+
+	b.batches <- batch
+	b.pending.Done()
+
+}
+
+func (b *BulkIndexer) tick() {
+
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.Flush()
+		case <-b.stop:
+			return
+		}
+	}
+
+}
+
+func (b *BulkIndexer) work() {
+
+	defer b.workers.Done()
+
+	for batch := range b.batches {
+
+		start := time.Now()
+
+		var err error
+		if b.config.Tolerant != nil {
+			err = b.instance.UpdateDocumentTolerant(batch.collection, nil, batch.documents, b.config.Tolerant)
+		} else {
+			err = b.instance.UpdateDocumentWithOptions(batch.collection, nil, batch.documents, b.config.Commit)
+		}
+
+		result := &BulkFlushResult{
+			Collection: batch.collection,
+			Documents:  batch.documents,
+			Bytes:      batch.bytes,
+			Duration:   time.Since(start),
+			Err:        err,
+		}
+
+		if err != nil && b.config.DeadLetter != nil {
+			result.DeadLetters, result.DeadLetterErr = b.deadLetter(batch, err)
+		}
+
+		for i := 0; i < len(batch.documents); i++ {
+			<-b.slots
+		}
+
+		if err != nil {
+			if b.config.OnFailure != nil {
+				b.config.OnFailure(result)
+			}
+		} else if b.config.OnFlush != nil {
+			b.config.OnFlush(result)
+		}
+	}
+
+}
+
+func (b *BulkIndexer) deadLetter(batch *bulkBuffer, err error) (int, error) {
+
+	letters, err := NewDeadLetters(batch.collection, batch.documents, err)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(letters); i++ {
+		if err := b.config.DeadLetter.Write(letters[i]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(letters), nil
+
+}
@@ -0,0 +1,108 @@
+package solr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitMode - how the updates are made visible
+type CommitMode int
+
+const (
+	// CommitHard - hard commit on each update (commit=true), the default
+	CommitHard CommitMode = iota
+	// CommitSoft - soft commit on each update (softCommit=true)
+	CommitSoft
+	// CommitWithin - asks solr to commit within CommitOptions.Within (commitWithin)
+	CommitWithin
+	// CommitNone - does not commit, relying on the autoCommit configuration
+	CommitNone
+)
+
+// CommitOptions - commit strategy for updates, for more information visit https://lucene.apache.org/solr/guide/7_4/uploading-data-with-index-handlers.html#commit-and-optimize-operations
+type CommitOptions struct {
+	Mode           CommitMode
+	Within         time.Duration //Within - the commitWithin time, used by the CommitWithin mode
+	NoWaitSearcher bool          //NoWaitSearcher - returns before the new searcher is opened (waitSearcher=false)
+	NoOpenSearcher bool          //NoOpenSearcher - commits without opening a new searcher (openSearcher=false), hard commits only
+	ExpungeDeletes bool          //ExpungeDeletes - merges away the segments with deleted documents, hard commits only
+}
+
+func (options *CommitOptions) writeQueryString(qs *strings.Builder) {
+
+	if options == nil {
+		qs.WriteString(stringCommit)
+		return
+	}
+
+	switch options.Mode {
+	case CommitHard:
+
+		qs.WriteString(stringCommit)
+
+		if options.NoOpenSearcher {
+			qs.WriteString(stringOpenSearcher)
+		}
+
+		if options.ExpungeDeletes {
+			qs.WriteString(stringExpungeDeletes)
+		}
+
+	case CommitSoft:
+
+		qs.WriteString(stringSoftCommit)
+
+	case CommitWithin:
+
+		qs.WriteString(stringCommitWithin)
+		qs.WriteString(strconv.FormatInt(int64(options.Within/time.Millisecond), 10))
+		return
+
+	default:
+
+		return
+	}
+
+	if options.NoWaitSearcher {
+		qs.WriteString(stringWaitSearcher)
+	}
+
+}
+
+// SetCommitOptions - sets the commit strategy used by UpdateDocument, nil restores the hard commit on each update
+func (s *Instance) SetCommitOptions(options *CommitOptions) {
+
+	s.commitOptions = options
+
+}
+
+// Commit - commits the pending updates, nil options means a hard commit, a failed commit returns a *SolrError
+func (s *Instance) Commit(instanceName string, options *CommitOptions) error {
+
+	if options == nil {
+		options = &CommitOptions{Mode: CommitHard}
+	}
+
+	return s.postUpdate(s.updateURL(instanceName, stringUpdate, nil, options), contentType, []byte(rawEmptyCommand))
+
+}
+
+// SoftCommit - makes the pending updates visible without flushing them to the disk
+func (s *Instance) SoftCommit(instanceName string) error {
+
+	return s.Commit(instanceName, &CommitOptions{Mode: CommitSoft})
+
+}
+
+// Optimize - merges the index down to maxSegments segments, zero uses the solr default of one segment, a failed optimize returns a *SolrError
+func (s *Instance) Optimize(instanceName string, maxSegments int) error {
+
+	params := map[string]string{stringOptimize: stringTrue}
+	if maxSegments > 0 {
+		params[stringMaxSegments] = strconv.Itoa(maxSegments)
+	}
+
+	return s.postUpdate(s.updateURL(instanceName, stringUpdate, params, &CommitOptions{Mode: CommitNone}), contentType, []byte(rawEmptyCommand))
+
+}
@@ -0,0 +1,45 @@
+package solr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitAndOptimizeErrors(t *testing.T) {
+
+	var method, query string
+	response := `{"responseHeader":{"status":0,"QTime":1}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, query = r.Method, r.URL.RawQuery
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	s := &Instance{coreURL: server.URL}
+
+	if assert.NoError(t, s.Commit("collection", nil)) {
+		assert.Equal(t, http.MethodPost, method)
+		assert.Equal(t, "&commit=true&wt=json", query)
+	}
+
+	if assert.NoError(t, s.Optimize("collection", 2)) {
+		assert.Equal(t, http.MethodPost, method)
+		assert.Contains(t, query, "maxSegments=2")
+	}
+
+	response = `{"responseHeader":{"status":500,"QTime":1},"error":{"msg":"commit failed","code":500}}`
+
+	for name, err := range map[string]error{"commit": s.Commit("collection", nil), "optimize": s.Optimize("collection", 0)} {
+
+		var solrError *SolrError
+		if assert.True(t, errors.As(err, &solrError), name) {
+			assert.Equal(t, 500, solrError.Code, name)
+			assert.Equal(t, "commit failed", solrError.Message, name)
+		}
+	}
+}
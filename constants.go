@@ -35,11 +35,236 @@ const (
 	stringQ                string = "&q="
 	stringFL               string = "&fl="
 	stringFQ               string = "&fq="
-	stringFacetTrue        string = "&facet=true&"
+	stringFacetTrue        string = "&facet=true"
 	stringEqual            string = "="
 	stringStart            string = "&start="
 	stringRows             string = "&rows="
 	stringUpdate           string = "/update?"
 	stringAmpersand        string = "&"
-	stringCommitTrue       string = "commit=true"
+
+	stringSpellcheckTrue              string = "&spellcheck=true"
+	stringSpellcheckQ                 string = "&spellcheck.q="
+	stringSpellcheckDictionary        string = "&spellcheck.dictionary="
+	stringSpellcheckCount             string = "&spellcheck.count="
+	stringSpellcheckCollateTrue       string = "&spellcheck.collate=true"
+	stringSpellcheckMaxCollationTries string = "&spellcheck.maxCollationTries="
+	stringSpellcheckExtendedTrue      string = "&spellcheck.extendedResults=true"
+	stringSpellcheckCollateExtTrue    string = "&spellcheck.collateExtendedResults=true"
+	rawSpellcheck                     string = "spellcheck"
+	rawSuggestions                    string = "suggestions"
+	rawSuggestion                     string = "suggestion"
+	rawCollations                     string = "collations"
+	rawCollationQuery                 string = "collationQuery"
+	rawHits                           string = "hits"
+	rawMisspellingsAndCorrections     string = "misspellingsAndCorrections"
+	rawCorrectlySpelled               string = "correctlySpelled"
+	rawStartOffset                    string = "startOffset"
+	rawEndOffset                      string = "endOffset"
+	rawOrigFreq                       string = "origFreq"
+	rawWord                           string = "word"
+	rawFreq                           string = "freq"
+
+	stringMLT                 string = "mlt?"
+	stringMLTTrue             string = "&mlt=true"
+	stringMLTFL               string = "&mlt.fl="
+	stringMLTMinDF            string = "&mlt.mindf="
+	stringMLTMinTF            string = "&mlt.mintf="
+	stringMLTMaxQT            string = "&mlt.maxqt="
+	stringMLTBoostTrue        string = "&mlt.boost=true"
+	stringMLTInterestingTerms string = "&mlt.interestingTerms="
+	stringMLTCount            string = "&mlt.count="
+	stringMLTMatchIncludeTrue string = "&mlt.match.include=true"
+	stringIDQuery             string = "id:"
+	stringComma               string = ","
+	contentTypeText           string = "text/plain; charset=utf-8"
+	rawMatch                  string = "match"
+	rawMoreLikeThis           string = "moreLikeThis"
+	rawInterestingTerms       string = "interestingTerms"
+	rawStart                  string = "start"
+	rawDocs                   string = "docs"
+	rawDocumentListPrefix     string = `{"response":`
+	rawDocumentListSuffix     string = `}`
+
+	stringStatsTrue      string = "&stats=true"
+	stringStatsField     string = "&stats.field="
+	stringStatsFacet     string = "&stats.facet="
+	stringLocalParams    string = "{!"
+	stringLocalParamsEnd string = "}"
+	stringSpace          string = " "
+	stringTrue           string = "true"
+	stringQuote          string = "'"
+	stringKey            string = "key"
+	stringPercentiles    string = "percentiles"
+	rawStats             string = "stats"
+	rawStatsFields       string = "stats_fields"
+	rawMin               string = "min"
+	rawMax               string = "max"
+	rawCount             string = "count"
+	rawMissing           string = "missing"
+	rawSum               string = "sum"
+	rawSumOfSquares      string = "sumOfSquares"
+	rawMean              string = "mean"
+	rawStddev            string = "stddev"
+	rawCountDistinct     string = "countDistinct"
+	rawDistinctValues    string = "distinctValues"
+	rawCardinality       string = "cardinality"
+	rawPercentiles       string = "percentiles"
+	rawFacets            string = "facets"
+
+	stringGet                   string = "get?"
+	stringID                    string = "&id="
+	rawDoc                      string = "doc"
+	rawID                       string = "id"
+	rawVersion                  string = "_version_"
+	rawEmptyDocumentList        string = `{"numFound":0,"start":0,"docs":[]}`
+	rawSingleDocumentListPrefix string = `{"numFound":1,"start":0,"docs":[`
+	rawSingleDocumentListSuffix string = `]}`
+
+	stringDebug                      string = "&debug="
+	stringDebugExplainStructuredTrue string = "&debug.explain.structured=true"
+	rawDebug                         string = "debug"
+	rawRawQueryString                string = "rawquerystring"
+	rawQueryString                   string = "querystring"
+	rawParsedQuery                   string = "parsedquery"
+	rawParsedQueryToString           string = "parsedquery_toString"
+	rawQParser                       string = "QParser"
+	rawFilterQueries                 string = "filter_queries"
+	rawParsedFilterQueries           string = "parsed_filter_queries"
+	rawExplain                       string = "explain"
+	rawTiming                        string = "timing"
+	rawTime                          string = "time"
+	rawPrepare                       string = "prepare"
+	rawProcess                       string = "process"
+	rawDescription                   string = "description"
+	rawDetails                       string = "details"
+	rawValue                         string = "value"
+
+	stringCommit         string = "&commit=true"
+	stringSoftCommit     string = "&softCommit=true"
+	stringCommitWithin   string = "&commitWithin="
+	stringWaitSearcher   string = "&waitSearcher=false"
+	stringOpenSearcher   string = "&openSearcher=false"
+	stringExpungeDeletes string = "&expungeDeletes=true"
+	stringOptimize       string = "optimize"
+	stringMaxSegments    string = "maxSegments"
+
+	atomicSet         string = "set"
+	atomicAdd         string = "add"
+	atomicAddDistinct string = "add-distinct"
+	atomicRemove      string = "remove"
+	atomicRemoveRegex string = "removeregex"
+	atomicInc         string = "inc"
+
+	rawAdd            string = "add"
+	rawDelete         string = "delete"
+	rawQuery          string = "query"
+	rawCommitWithin   string = "commitWithin"
+	rawOverwrite      string = "overwrite"
+	rawRoute          string = "_route_"
+	rawCommit         string = "commit"
+	rawSoftCommit     string = "softCommit"
+	rawOptimize       string = "optimize"
+	rawWaitSearcher   string = "waitSearcher"
+	rawOpenSearcher   string = "openSearcher"
+	rawExpungeDeletes string = "expungeDeletes"
+	rawMaxSegments    string = "maxSegments"
+
+	stringQuestion                  string = "?"
+	stringUpdatePath                string = "/update"
+	stringUpdateCSV                 string = "/update/csv"
+	stringUpdateJSONDocs            string = "/update/json/docs"
+	contentTypeCSV                  string = "application/csv; charset=utf-8"
+	contentTypeXML                  string = "application/xml; charset=utf-8"
+	rawChildDocuments               string = "_childDocuments_"
+	csvSeparator                    string = "separator"
+	csvHeader                       string = "header"
+	csvFieldNames                   string = "fieldnames"
+	csvSplit                        string = "split"
+	csvFieldPrefix                  string = "f."
+	csvSplitSuffix                  string = ".split"
+	csvSeparatorSuffix              string = ".separator"
+	stringFalse                     string = "false"
+	headerContentType               string = "Content-Type"
+	headerContentEncoding           string = "Content-Encoding"
+	encodingGzip                    string = "gzip"
+	stringUpdateChain               string = "update.chain"
+	stringMaxErrors                 string = "maxErrors"
+	tolerantChain                   string = "tolerant"
+	updateAdd                       string = "ADD"
+	updateDeleteByQuery             string = "DELQ"
+	tagOmitEmpty                    string = "omitempty"
+	tagDynamic                      string = "dynamic"
+	solrDateFormat                  string = "2006-01-02T15:04:05.999Z"
+	rawMaxScore                     string = "maxScore"
+	rawPartialResults               string = "partialResults"
+	rawZkConnected                  string = "zkConnected"
+	rawSegmentTerminatedEarly       string = "segmentTerminatedEarly"
+	rawParams                       string = "params"
+	rawNextCursorMark               string = "nextCursorMark"
+	stringCursorMark                string = "&cursorMark="
+	wtJavabin                       string = "&wt=javabin"
+	stringNull                      string = "null"
+	contentTypeForm                 string = "application/x-www-form-urlencoded"
+	stringWT                        string = "wt"
+	stringJavabin                   string = "javabin"
+	stringDF                        string = "&df="
+	stringQOp                       string = "&q.op="
+	stringDefType                   string = "&defType="
+	stringTimeAllowed               string = "&timeAllowed="
+	stringSegmentTerminateEarlyTrue string = "&segmentTerminateEarly=true"
+	stringShards                    string = "&shards="
+	stringFacetParam                string = "facet"
+	edismaxParser                   string = "edismax"
+	stringParamQF                   string = "qf"
+	stringParamPF                   string = "pf"
+	stringParamPF2                  string = "pf2"
+	stringParamPF3                  string = "pf3"
+	stringParamMM                   string = "mm"
+	stringParamTie                  string = "tie"
+	stringParamBQ                   string = "bq"
+	stringParamBF                   string = "bf"
+	stringParamBoost                string = "boost"
+	stringParamUF                   string = "uf"
+	stringParamPS                   string = "ps"
+	stringParamQS                   string = "qs"
+	stringParamV                    string = "v"
+	stringQF                        string = "&qf="
+	stringPF                        string = "&pf="
+	stringPF2                       string = "&pf2="
+	stringPF3                       string = "&pf3="
+	stringMM                        string = "&mm="
+	stringTie                       string = "&tie="
+	stringBQ                        string = "&bq="
+	stringBF                        string = "&bf="
+	stringBoost                     string = "&boost="
+	stringUF                        string = "&uf="
+	stringPS                        string = "&ps="
+	stringQS                        string = "&qs="
+	stringParenthesis               string = "("
+	stringParenthesisEnd            string = ")"
+	stringColon                     string = ":"
+	stringPlus                      string = "+"
+	stringRangeStart                string = "["
+	stringRangeTo                   string = " TO "
+	stringRangeEnd                  string = "]"
+	sortAsc                         string = "asc"
+	sortDesc                        string = "desc"
+	funcField                       string = "field"
+	funcRecip                       string = "recip"
+	funcMs                          string = "ms"
+	funcLog                         string = "log"
+	funcSum                         string = "sum"
+	funcProduct                     string = "product"
+	funcIf                          string = "if"
+	funcExists                      string = "exists"
+	funcQuery                       string = "query"
+	funcFrange                      string = "frange"
+	stringFrangeLower               string = "l"
+	stringFrangeUpper               string = "u"
+	dateNow                         string = "NOW"
+	stringWildcard                  string = "*"
+	stringArrayStart                string = "["
+	stringArrayEnd                  string = "]"
+	stringNewLine                   string = "\n"
+	rawEmptyCommand                 string = "{}"
 )
@@ -0,0 +1,241 @@
+package solr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+//DeadLetter - a document that failed to be indexed
+type DeadLetter struct {
+	Collection string          `json:"collection"`
+	Document   json.RawMessage `json:"document"`
+	Error      string          `json:"error"`
+	Code       int             `json:"code,omitempty"` //Code - the solr status, zero when solr did not answer
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+// NewDeadLetters - creates the dead letters of the documents rejected by an update, when the error is a
+// *PartialFailureError only the rejected documents are returned with their own messages
+func NewDeadLetters(collection string, documents []interface{}, err error) ([]*DeadLetter, error) {
+
+	if err == nil {
+		return nil, nil
+	}
+
+	letter := DeadLetter{
+		Collection: collection,
+		Error:      err.Error(),
+		Timestamp:  time.Now().UTC(),
+	}
+
+	var solrError *SolrError
+	var conflict *VersionConflictError
+	var partial *PartialFailureError
+
+	switch {
+	case errors.As(err, &partial):
+		letter.Code = partial.Code
+	case errors.As(err, &conflict):
+		letter.Code = conflict.Code
+	case errors.As(err, &solrError):
+		letter.Code = solrError.Code
+	}
+
+	var messages map[string]string
+	if partial != nil && !partial.Aborted {
+
+		messages = map[string]string{}
+		for i := 0; i < len(partial.Errors); i++ {
+			if partial.Errors[i].Type == updateAdd {
+				messages[partial.Errors[i].ID] = partial.Errors[i].Message
+			}
+		}
+	}
+
+	var letters []*DeadLetter
+
+	for i := 0; i < len(documents); i++ {
+
+		raw, err := json.Marshal(documents[i])
+		if err != nil {
+			return nil, err
+		}
+
+		current := letter
+		current.Document = raw
+
+		if messages != nil {
+
+			id, err := documentID(documents[i])
+			if err != nil {
+				return nil, err
+			}
+
+			message, ok := messages[id]
+			if !ok {
+				continue
+			}
+
+			current.Error = message
+		}
+
+		letters = append(letters, &current)
+	}
+
+	return letters, nil
+
+}
+
+// FileDeadLetterSink - writes the dead letters as JSON lines to a local file
+type FileDeadLetterSink struct {
+	file *os.File
+	lock sync.Mutex
+}
+
+// NewFileDeadLetterSink - opens the file for appending, creating it if needed
+func NewFileDeadLetterSink(path string) (*FileDeadLetterSink, error) {
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileDeadLetterSink{file: file}, nil
+
+}
+
+// Write - appends the dead letter to the file
+func (sink *FileDeadLetterSink) Write(letter *DeadLetter) error {
+
+	line, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	_, err = sink.file.Write(line)
+
+	return err
+
+}
+
+// Close - closes the file
+func (sink *FileDeadLetterSink) Close() error {
+
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	return sink.file.Close()
+
+}
+
+// ReplayOptions - options of ReplayDeadLetters
+type ReplayOptions struct {
+	BatchSize int              //BatchSize - the number of documents of each update, default 1000
+	Tolerant  *TolerantOptions //Tolerant - sends the documents with UpdateDocumentTolerant, so only the invalid documents fail
+	Sink      DeadLetterSink   //Sink - receives the documents failing again, when nil the replay stops on the first error
+}
+
+// ReplayDeadLetters - resubmits the documents of a dead letter file to their collections, returning the number of indexed documents
+func (s *Instance) ReplayDeadLetters(path string, options *ReplayOptions) (int, error) {
+
+	if options == nil {
+		options = &ReplayOptions{}
+	}
+
+	if options.BatchSize < 0 {
+		return 0, fmt.Errorf("BatchSize cannot be negative")
+	}
+
+	batchSize := options.BatchSize
+	if batchSize == 0 {
+		batchSize = 1000
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	batches := map[string][]interface{}{}
+	replayed := 0
+
+	send := func(collection string) error {
+
+		documents := batches[collection]
+		delete(batches, collection)
+
+		var err error
+		if options.Tolerant != nil {
+			err = s.UpdateDocumentTolerant(collection, nil, documents, options.Tolerant)
+		} else {
+			err = s.UpdateDocument(collection, nil, documents)
+		}
+
+		if err == nil {
+			replayed += len(documents)
+			return nil
+		}
+
+		if options.Sink == nil {
+			return err
+		}
+
+		letters, err := NewDeadLetters(collection, documents, err)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(letters); i++ {
+			if err := options.Sink.Write(letters[i]); err != nil {
+				return err
+			}
+		}
+
+		replayed += len(documents) - len(letters)
+
+		return nil
+	}
+
+	for {
+
+		letter := DeadLetter{}
+
+		err := decoder.Decode(&letter)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return replayed, err
+		}
+
+		batches[letter.Collection] = append(batches[letter.Collection], letter.Document)
+
+		if len(batches[letter.Collection]) >= batchSize {
+			if err := send(letter.Collection); err != nil {
+				return replayed, err
+			}
+		}
+	}
+
+	for collection := range batches {
+		if err := send(collection); err != nil {
+			return replayed, err
+		}
+	}
+
+	return replayed, nil
+
+}
@@ -0,0 +1,348 @@
+package solr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+const (
+	// DebugQuery - returns the parsed query
+	DebugQuery string = "query"
+	// DebugTiming - returns the time spent by each search component
+	DebugTiming string = "timing"
+	// DebugResults - returns the score explanation of each document
+	DebugResults string = "results"
+	// DebugAll - returns all the debug information
+	DebugAll string = "all"
+)
+
+// DebugParams - Params for the debug output, for more information visit https://lucene.apache.org/solr/guide/7_4/common-query-parameters.html#debug-parameter
+type DebugParams struct {
+	Debug             []string //Debug - one or more of DebugQuery, DebugTiming, DebugResults or DebugAll
+	ExplainStructured bool     //ExplainStructured - returns the explanations as trees instead of text
+}
+
+func (params *DebugParams) writeQueryString(qs *strings.Builder) {
+
+	for i := 0; i < len(params.Debug); i++ {
+		writeParam(qs, stringDebug, params.Debug[i])
+	}
+
+	if params.ExplainStructured {
+		qs.WriteString(stringDebugExplainStructuredTrue)
+	}
+
+}
+
+//Debug - debug section from the solr response
+type Debug struct {
+	RawQueryString      string
+	QueryString         string
+	ParsedQuery         string
+	ParsedQueryToString string
+	QParser             string
+	FilterQueries       []string
+	ParsedFilterQueries []string
+	Timing              *DebugTimings
+	Explain             map[string]*Explanation //Explain - the structured explanations by document id
+	ExplainText         map[string]string       //ExplainText - the text explanations by document id
+}
+
+//DebugTimings - the time spent in each phase, in milliseconds
+type DebugTimings struct {
+	Time    float64
+	Prepare []ComponentTiming
+	Process []ComponentTiming
+}
+
+//ComponentTiming - the time spent by a search component, in milliseconds
+type ComponentTiming struct {
+	Name string
+	Time float64
+}
+
+//Explanation - a score explanation tree
+type Explanation struct {
+	Match       bool
+	Value       float64
+	Description string
+	Details     []*Explanation
+}
+
+// String - pretty prints the explanation tree
+func (e *Explanation) String() string {
+
+	out := strings.Builder{}
+	e.write(&out, 0)
+
+	return out.String()
+
+}
+
+func (e *Explanation) write(out *strings.Builder, depth int) {
+
+	out.WriteString(strings.Repeat("  ", depth))
+	out.WriteString(strconv.FormatFloat(e.Value, 'g', -1, 64))
+	out.WriteString(" = ")
+	out.WriteString(e.Description)
+	out.WriteString("\n")
+
+	for i := 0; i < len(e.Details); i++ {
+		e.Details[i].write(out, depth+1)
+	}
+
+}
+
+// String - pretty prints the debug output
+func (d *Debug) String() string {
+
+	out := strings.Builder{}
+
+	if d.RawQueryString != "" || d.ParsedQuery != "" {
+		fmt.Fprintf(&out, "query: %s\n", d.RawQueryString)
+		fmt.Fprintf(&out, "parsed query: %s\n", d.ParsedQuery)
+		fmt.Fprintf(&out, "query parser: %s\n", d.QParser)
+	}
+
+	for i := 0; i < len(d.FilterQueries); i++ {
+		fmt.Fprintf(&out, "filter query: %s\n", d.FilterQueries[i])
+		if i < len(d.ParsedFilterQueries) {
+			fmt.Fprintf(&out, "parsed filter query: %s\n", d.ParsedFilterQueries[i])
+		}
+	}
+
+	if d.Timing != nil {
+
+		fmt.Fprintf(&out, "timing: %gms\n", d.Timing.Time)
+
+		for i := 0; i < len(d.Timing.Prepare); i++ {
+			fmt.Fprintf(&out, "  prepare %s: %gms\n", d.Timing.Prepare[i].Name, d.Timing.Prepare[i].Time)
+		}
+
+		for i := 0; i < len(d.Timing.Process); i++ {
+			fmt.Fprintf(&out, "  process %s: %gms\n", d.Timing.Process[i].Name, d.Timing.Process[i].Time)
+		}
+	}
+
+	ids := make([]string, 0, len(d.Explain)+len(d.ExplainText))
+	for id := range d.Explain {
+		ids = append(ids, id)
+	}
+	for id := range d.ExplainText {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if explain, ok := d.Explain[id]; ok {
+			fmt.Fprintf(&out, "explain %s:\n%s", id, explain.String())
+		} else {
+			fmt.Fprintf(&out, "explain %s:\n%s\n", id, strings.TrimSpace(d.ExplainText[id]))
+		}
+	}
+
+	return out.String()
+
+}
+
+func (s *Instance) parserDebug(root responseNode) (*Debug, error) {
+
+	value, ok := root.Get(rawDebug)
+	if !ok {
+		return nil, nil
+	}
+
+	debug := &Debug{}
+
+	err := value.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		switch key {
+		case rawRawQueryString:
+			debug.RawQueryString, err = parseDebugString(value)
+		case rawQueryString:
+			debug.QueryString, err = parseDebugString(value)
+		case rawParsedQuery:
+			debug.ParsedQuery, err = parseDebugString(value)
+		case rawParsedQueryToString:
+			debug.ParsedQueryToString, err = parseDebugString(value)
+		case rawQParser:
+			debug.QParser, err = parseDebugString(value)
+		case rawFilterQueries:
+			debug.FilterQueries, err = parseDebugStrings(value)
+		case rawParsedFilterQueries:
+			debug.ParsedFilterQueries, err = parseDebugStrings(value)
+		case rawTiming:
+			debug.Timing, err = parseDebugTimings(value)
+		case rawExplain:
+			err = parseDebugExplain(debug, value)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return debug, nil
+
+}
+
+// parseDebugString - a string, the other values are returned as JSON
+func parseDebugString(node responseNode) (string, error) {
+
+	switch node.Kind() {
+	case jsonparser.String, jsonparser.Null:
+		return node.Text()
+	default:
+
+		raw, err := node.JSON()
+		if err != nil {
+			return "", err
+		}
+
+		return string(raw), nil
+	}
+
+}
+
+func parseDebugStrings(node responseNode) ([]string, error) {
+
+	var values []string
+
+	err := node.Items(func(value responseNode) error {
+
+		v, err := parseDebugString(value)
+		values = append(values, v)
+		return err
+	})
+
+	return values, err
+
+}
+
+func parseDebugTimings(node responseNode) (*DebugTimings, error) {
+
+	timings := &DebugTimings{}
+
+	err := node.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		switch key {
+		case rawTime:
+			timings.Time, err = value.Float()
+		case rawPrepare:
+			timings.Prepare, err = parseComponentTimings(value)
+		case rawProcess:
+			timings.Process, err = parseComponentTimings(value)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return timings, nil
+
+}
+
+func parseComponentTimings(node responseNode) ([]ComponentTiming, error) {
+
+	var timings []ComponentTiming
+
+	err := node.Each(func(name string, keyType jsonparser.ValueType, value responseNode) error {
+
+		if value.Kind() != jsonparser.Object {
+			return nil
+		}
+
+		component, ok := value.Get(rawTime)
+		if !ok {
+			return nil
+		}
+
+		time, err := component.Float()
+		if err != nil {
+			return err
+		}
+
+		timings = append(timings, ComponentTiming{
+			Name: name,
+			Time: time,
+		})
+
+		return nil
+	})
+
+	return timings, err
+
+}
+
+func parseDebugExplain(debug *Debug, node responseNode) error {
+
+	return node.Each(func(id string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		if value.Kind() == jsonparser.String {
+
+			if debug.ExplainText == nil {
+				debug.ExplainText = map[string]string{}
+			}
+
+			debug.ExplainText[id], err = value.Text()
+			return err
+		}
+
+		if debug.Explain == nil {
+			debug.Explain = map[string]*Explanation{}
+		}
+
+		debug.Explain[id], err = parseExplanation(value)
+		return err
+	})
+
+}
+
+func parseExplanation(node responseNode) (*Explanation, error) {
+
+	explanation := &Explanation{}
+
+	err := node.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		switch key {
+		case rawMatch:
+			explanation.Match, err = value.Bool()
+		case rawValue:
+			explanation.Value, err = value.Float()
+		case rawDescription:
+			explanation.Description, err = value.Text()
+		case rawDetails:
+
+			err = value.Items(func(value responseNode) error {
+
+				detail, err := parseExplanation(value)
+				if err != nil {
+					return err
+				}
+
+				explanation.Details = append(explanation.Details, detail)
+				return nil
+			})
+		}
+
+		return err
+	})
+
+	return explanation, err
+
+}
@@ -1,98 +1,489 @@
 package solr
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/buger/jsonparser"
 )
 
+// responseNode - a value of a search response, the section parsers read the JSON and the javabin responses through it,
+// the kinds are the jsonparser value types: the named lists, maps and document lists are objects
+type responseNode interface {
+	// Get - the value at the keys path, false when it does not exist
+	Get(keys ...string) (responseNode, bool)
+	// Each - iterates over an object or a flat named list, the keyType is jsonparser.String for the object keys
+	// and may be null, a number or a boolean for the names of a flat named list
+	Each(callback func(key string, keyType jsonparser.ValueType, value responseNode) error) error
+	// Items - iterates over an array
+	Items(callback func(value responseNode) error) error
+	// Kind - the jsonparser type of the value
+	Kind() jsonparser.ValueType
+	// Int - the value as an integer
+	Int() (int64, error)
+	// Float - the value as a float, null is zero
+	Float() (float64, error)
+	// Bool - the value as a boolean
+	Bool() (bool, error)
+	// Text - the value as a string, null is empty
+	Text() (string, error)
+	// JSON - the value written as JSON
+	JSON() ([]byte, error)
+}
+
 //Decode - decode a raw byte from solr instance and return a formated response
 func (s *Instance) Decode(raw []byte, facet bool) (*Response, error) {
 
+	return s.decode(raw, facet, s.documentParser)
+}
+
+func (s *Instance) decode(raw []byte, facet bool, parser DocumentParser) (*Response, error) {
+
+	return s.decodeResponse(jsonNode{raw: raw, dataType: jsonparser.Object}, facet, func() (interface{}, error) {
+		return parser.Parse(raw)
+	})
+
+}
+
+// decodeResponse - decodes the sections of a JSON or javabin response, the documents are parsed by the format
+func (s *Instance) decodeResponse(root responseNode, facet bool, documents func() (interface{}, error)) (*Response, error) {
+
 	res := &Response{}
 	var err error
 
-	if res.NumFound, res.Status, res.QTime, err = s.parserNumbers(raw); err != nil {
+	if res.NumFound, res.Status, res.QTime, err = s.parserNumbers(root); err != nil {
 		return nil, fmt.Errorf("error parsing numbers: %v", err.Error())
 	}
 
-	if res.Docs, err = s.documentParser.Parse(raw); err != nil {
+	if res.Header, err = s.parserHeader(root); err != nil {
+		return nil, fmt.Errorf("error parsing header: %v", err.Error())
+	}
+
+	if res.Docs, err = documents(); err != nil {
 		return nil, fmt.Errorf("error parsing docs: %v", err.Error())
 	}
 
 	if facet {
-		var errors []error
-		if res.Facets, errors = s.parserFacets(raw); err != nil {
-			return nil, fmt.Errorf("error parsing facets: %v", errors)
+		if res.Facets, err = s.parserFacets(root); err != nil {
+			return nil, fmt.Errorf("error parsing facets: %w", err)
 		}
 	}
 
+	if res.JSONFacets, err = s.parserJSONFacets(root); err != nil {
+		return nil, fmt.Errorf("error parsing json facets: %v", err.Error())
+	}
+
+	if res.Spellcheck, err = s.parserSpellcheck(root); err != nil {
+		return nil, fmt.Errorf("error parsing spellcheck: %v", err.Error())
+	}
+
+	if res.Stats, err = s.parserStats(root); err != nil {
+		return nil, fmt.Errorf("error parsing stats: %v", err.Error())
+	}
+
+	if res.Debug, err = s.parserDebug(root); err != nil {
+		return nil, fmt.Errorf("error parsing debug: %v", err.Error())
+	}
+
 	return res, err
 
 }
 
-func (s *Instance) parserFacets(raw []byte) ([]FacetField, []error) {
+// parserFacets - parses the facet_fields, each field has its own values and the errors are returned as *FacetError
+func (s *Instance) parserFacets(root responseNode) ([]FacetField, error) {
 
-	facetValueArray := []FacetValue{}
-	facetValues := FacetValue{}
-	facetFields := FacetField{}
-	var facetFieldsArray []FacetField
-	var err error
-	var k string
-	var v int64
-	var errors []error
+	facetFields, ok := root.Get(rawFacetsCount, rawFacetFields)
+	if !ok {
+		return nil, nil
+	}
 
-	err = jsonparser.ObjectEach(raw, func(key, value []byte, dataType jsonparser.ValueType, offset int) error {
+	if facetFields.Kind() != jsonparser.Object {
+		return nil, &FacetError{Err: fmt.Errorf("unexpected %s type: %s", rawFacetFields, facetFields.Kind())}
+	}
 
-		_, err = jsonparser.ArrayEach(value, func(tvalue []byte, dataType jsonparser.ValueType, offset int, err error) {
+	var fields []FacetField
 
-			if err != nil {
-				errors = append(errors, err)
-			}
+	err := facetFields.Each(func(field string, keyType jsonparser.ValueType, value responseNode) error {
+
+		facetField := FacetField{Name: field, List: []FacetValue{}}
+
+		err := value.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
 
-			switch dataType {
-			case jsonparser.String:
-				k = string(tvalue)
-			case jsonparser.Number:
-				v, err = jsonparser.GetInt(tvalue)
-				if err != nil {
-					errors = append(errors, err)
-				}
-				facetValues.Name = k
-				facetValues.Value = v
-				facetValueArray = append(facetValueArray, facetValues)
+			facetValue, err := parseFacetValue(key, keyType, value)
+			if err != nil {
+				return err
 			}
 
+			facetField.List = append(facetField.List, facetValue)
+			return nil
 		})
 		if err != nil {
-			return err
+			return &FacetError{Field: field, Err: err}
 		}
 
-		facetFields.Name = string(key)
-		facetFields.List = facetValueArray
-		facetFieldsArray = append(facetFieldsArray, facetFields)
+		fields = append(fields, facetField)
 		return nil
-	}, rawFacetsCount, rawFacetFields)
+	})
 	if err != nil {
-		return nil, errors
+
+		var facetError *FacetError
+		if !errors.As(err, &facetError) {
+			err = &FacetError{Err: err}
+		}
+
+		return nil, err
 	}
 
-	return facetFieldsArray, nil
+	return fields, nil
 
 }
 
-func (s *Instance) parserNumbers(raw []byte) (found, status, qtime int64, err error) {
+// parseFacetValue - parses a facet value, the key is null for the facet.missing count
+// and it may be a number or a boolean for non string fields
+func parseFacetValue(key string, keyType jsonparser.ValueType, value responseNode) (FacetValue, error) {
+
+	facetValue := FacetValue{}
+	var err error
 
-	if found, err = jsonparser.GetInt(raw, rawResponse, rawNumFound); err != nil {
+	switch keyType {
+	case jsonparser.Null:
+		facetValue.Missing = true
+	case jsonparser.String, jsonparser.Number, jsonparser.Boolean:
+		facetValue.Name = key
+	default:
+		return facetValue, fmt.Errorf("unexpected facet key type: %s", keyType)
+	}
+
+	if facetValue.Value, err = value.Int(); err != nil {
+		return facetValue, fmt.Errorf("invalid count of %q: %v", facetValue.Name, err)
+	}
+
+	return facetValue, nil
+
+}
+
+func (s *Instance) parserNumbers(root responseNode) (found, status, qtime int64, err error) {
+
+	if found, err = nodeInt(root, rawResponse, rawNumFound); err != nil {
 		return found, status, qtime, err
 	}
-	if status, err = jsonparser.GetInt(raw, rawResponseHeader, rawStatus); err != nil {
+	if status, err = nodeInt(root, rawResponseHeader, rawStatus); err != nil {
 		return found, status, qtime, err
 	}
-	if qtime, err = jsonparser.GetInt(raw, rawResponseHeader, rawQtime); err != nil {
+	if qtime, err = nodeInt(root, rawResponseHeader, rawQtime); err != nil {
 		return found, status, qtime, err
 	}
 
 	return found, status, qtime, err
 
 }
+
+func (s *Instance) parserHeader(root responseNode) (*ResponseHeader, error) {
+
+	header := &ResponseHeader{}
+	var err error
+
+	if start, ok := root.Get(rawResponse, rawStart); ok {
+		if header.Start, err = start.Int(); err != nil {
+			return nil, err
+		}
+	}
+
+	if maxScore, ok := root.Get(rawResponse, rawMaxScore); ok {
+		if header.MaxScore, err = maxScore.Float(); err != nil {
+			return nil, err
+		}
+	}
+
+	if responseHeader, ok := root.Get(rawResponseHeader); ok {
+
+		err = responseHeader.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+			var err error
+
+			switch key {
+			case rawPartialResults:
+				header.PartialResults, err = value.Bool()
+			case rawZkConnected:
+				header.ZkConnected, err = value.Bool()
+			case rawSegmentTerminatedEarly:
+				header.SegmentTerminatedEarly, err = value.Bool()
+			case rawParams:
+				header.Params, err = parseHeaderParams(value)
+			}
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cursorMark, ok := root.Get(rawNextCursorMark); ok {
+		if header.NextCursorMark, err = cursorMark.Text(); err != nil {
+			return nil, err
+		}
+	}
+
+	return header, nil
+
+}
+
+// parseHeaderParams - the echoed params, a param is a string or an array of strings when repeated
+func parseHeaderParams(node responseNode) (map[string][]string, error) {
+
+	params := map[string][]string{}
+
+	err := node.Each(func(name string, keyType jsonparser.ValueType, value responseNode) error {
+
+		if value.Kind() != jsonparser.Array {
+
+			param, err := parseDebugString(value)
+			if err != nil {
+				return err
+			}
+
+			params[name] = append(params[name], param)
+			return nil
+		}
+
+		values, err := parseDebugStrings(value)
+		if err != nil {
+			return err
+		}
+
+		params[name] = append(params[name], values...)
+		return nil
+	})
+
+	return params, err
+
+}
+
+// nodeInt - the integer at the keys path, an error when it does not exist
+func nodeInt(node responseNode, keys ...string) (int64, error) {
+
+	value, ok := node.Get(keys...)
+	if !ok {
+		return 0, fmt.Errorf("%s not found", strings.Join(keys, "."))
+	}
+
+	return value.Int()
+
+}
+
+// nodeText - the string at the keys path, an error when it does not exist
+func nodeText(node responseNode, keys ...string) (string, error) {
+
+	value, ok := node.Get(keys...)
+	if !ok {
+		return "", fmt.Errorf("%s not found", strings.Join(keys, "."))
+	}
+
+	return value.Text()
+
+}
+
+// jsonNode - a value of a JSON response, the strings are kept escaped as jsonparser returns them
+type jsonNode struct {
+	raw      []byte
+	dataType jsonparser.ValueType
+}
+
+func (n jsonNode) Get(keys ...string) (responseNode, bool) {
+
+	value, dataType, _, err := jsonparser.Get(n.raw, keys...)
+	if err != nil {
+		return nil, false
+	}
+
+	return jsonNode{raw: value, dataType: dataType}, true
+
+}
+
+func (n jsonNode) Each(callback func(key string, keyType jsonparser.ValueType, value responseNode) error) error {
+
+	if n.dataType != jsonparser.Object && n.dataType != jsonparser.Array && n.dataType != jsonparser.Null {
+		return fmt.Errorf("unexpected named list type: %s", n.dataType)
+	}
+
+	return eachNamedListTyped(n.raw, func(key []byte, keyType jsonparser.ValueType, value []byte, dataType jsonparser.ValueType) error {
+
+		name := string(key)
+
+		switch keyType {
+		case jsonparser.String:
+
+			var err error
+			if name, err = jsonparser.ParseString(key); err != nil {
+				return err
+			}
+
+		case jsonparser.Null:
+
+			name = ""
+		}
+
+		return callback(name, keyType, jsonNode{raw: value, dataType: dataType})
+	})
+
+}
+
+func (n jsonNode) Items(callback func(value responseNode) error) error {
+
+	switch n.dataType {
+	case jsonparser.Array:
+	case jsonparser.Null:
+		return nil
+	default:
+		return fmt.Errorf("unexpected list type: %s", n.dataType)
+	}
+
+	var parseError error
+
+	_, err := jsonparser.ArrayEach(n.raw, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+		if parseError != nil {
+			return
+		}
+
+		if err != nil {
+			parseError = err
+			return
+		}
+
+		parseError = callback(jsonNode{raw: value, dataType: dataType})
+	})
+	if err != nil {
+		return err
+	}
+
+	return parseError
+
+}
+
+func (n jsonNode) Kind() jsonparser.ValueType {
+
+	return n.dataType
+}
+
+func (n jsonNode) Int() (int64, error) {
+
+	return jsonparser.ParseInt(n.raw)
+}
+
+func (n jsonNode) Float() (float64, error) {
+
+	if n.dataType == jsonparser.Null {
+		return 0, nil
+	}
+
+	return jsonparser.ParseFloat(n.raw)
+
+}
+
+func (n jsonNode) Bool() (bool, error) {
+
+	return jsonparser.ParseBoolean(n.raw)
+}
+
+func (n jsonNode) Text() (string, error) {
+
+	switch n.dataType {
+	case jsonparser.String:
+		return jsonparser.ParseString(n.raw)
+	case jsonparser.Null:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected string type: %s", n.dataType)
+	}
+
+}
+
+func (n jsonNode) JSON() ([]byte, error) {
+
+	if n.dataType == jsonparser.String {
+		return []byte(`"` + string(n.raw) + `"`), nil
+	}
+
+	return n.raw, nil
+
+}
+
+// eachNamedList - iterates over a solr named list found at the keys path, it accepts
+// both the flat array ([key, value, key, value]) and the map (json.nl=map) formats
+func eachNamedList(raw []byte, callback func(key, value []byte, dataType jsonparser.ValueType) error, keys ...string) error {
+
+	return eachNamedListTyped(raw, func(key []byte, keyType jsonparser.ValueType, value []byte, dataType jsonparser.ValueType) error {
+
+		if keyType != jsonparser.String {
+			key = nil
+		}
+
+		return callback(key, value, dataType)
+	}, keys...)
+
+}
+
+// eachNamedListTyped - same as eachNamedList but the callback also receives the type of the key,
+// the keys of the flat array format may be null, numbers or booleans
+func eachNamedListTyped(raw []byte, callback func(key []byte, keyType jsonparser.ValueType, value []byte, dataType jsonparser.ValueType) error, keys ...string) error {
+
+	value, dataType, _, err := jsonparser.Get(raw, keys...)
+	if err == jsonparser.KeyPathNotFoundError {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch dataType {
+	case jsonparser.Object:
+
+		return jsonparser.ObjectEach(value, func(key, value []byte, dataType jsonparser.ValueType, offset int) error {
+			return callback(key, jsonparser.String, value, dataType)
+		})
+
+	case jsonparser.Array:
+
+		var key []byte
+		var keyType jsonparser.ValueType
+		var index int
+		var parseError error
+
+		_, err = jsonparser.ArrayEach(value, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+			if parseError != nil {
+				return
+			}
+
+			if err != nil {
+				parseError = err
+				return
+			}
+
+			if index%2 == 0 {
+				key, keyType = value, dataType
+			} else {
+				parseError = callback(key, keyType, value, dataType)
+			}
+
+			index++
+		})
+		if err != nil {
+			return err
+		}
+
+		return parseError
+
+	case jsonparser.Null:
+
+		return nil
+
+	default:
+
+		return fmt.Errorf("unexpected named list type: %s", dataType)
+	}
+
+}
@@ -0,0 +1,124 @@
+package solr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// facetResponse - a search response with the facet_fields
+func facetResponse(facetFields string) []byte {
+
+	return []byte(`{"responseHeader":{"status":0,"QTime":1},"response":{"numFound":0,"start":0,"docs":[]},` +
+		`"facet_counts":{"facet_queries":{},"facet_fields":` + facetFields + `}}`)
+}
+
+func TestDecodeFacets(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		facetFields string
+		expected    []FacetField
+	}{
+		{
+			name:        "each field has its own values",
+			facetFields: `{"tag_key":["host",3,"ttl",1],"tag_value":["host1",2,"host2",1,"host3",0]}`,
+			expected: []FacetField{
+				{Name: "tag_key", List: []FacetValue{{Name: "host", Value: 3}, {Name: "ttl", Value: 1}}},
+				{Name: "tag_value", List: []FacetValue{{Name: "host1", Value: 2}, {Name: "host2", Value: 1}, {Name: "host3", Value: 0}}},
+			},
+		},
+		{
+			name:        "empty field between fields",
+			facetFields: `{"a":["x",1],"b":[],"c":["y",2]}`,
+			expected: []FacetField{
+				{Name: "a", List: []FacetValue{{Name: "x", Value: 1}}},
+				{Name: "b", List: []FacetValue{}},
+				{Name: "c", List: []FacetValue{{Name: "y", Value: 2}}},
+			},
+		},
+		{
+			name:        "json.nl=map",
+			facetFields: `{"tag_value":{"host1":2,"host2":1}}`,
+			expected: []FacetField{
+				{Name: "tag_value", List: []FacetValue{{Name: "host1", Value: 2}, {Name: "host2", Value: 1}}},
+			},
+		},
+		{
+			name:        "int, float, bool and missing keys",
+			facetFields: `{"points":[10,3,-1,1],"ratio":[0.5,2,1e3,1],"parent_doc":[true,4,false,1],"owner":["a",1,null,5]}`,
+			expected: []FacetField{
+				{Name: "points", List: []FacetValue{{Name: "10", Value: 3}, {Name: "-1", Value: 1}}},
+				{Name: "ratio", List: []FacetValue{{Name: "0.5", Value: 2}, {Name: "1e3", Value: 1}}},
+				{Name: "parent_doc", List: []FacetValue{{Name: "true", Value: 4}, {Name: "false", Value: 1}}},
+				{Name: "owner", List: []FacetValue{{Name: "a", Value: 1}, {Value: 5, Missing: true}}},
+			},
+		},
+		{
+			name:        "escaped names",
+			facetFields: `{"tag_value":["a\"b",1]}`,
+			expected: []FacetField{
+				{Name: "tag_value", List: []FacetValue{{Name: `a"b`, Value: 1}}},
+			},
+		},
+	}
+
+	s := &Instance{}
+
+	for _, c := range cases {
+
+		res, err := s.decode(facetResponse(c.facetFields), true, &DefaultDocumentParser{})
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, c.expected, res.Facets, c.name)
+		}
+	}
+}
+
+func TestDecodeFacetErrors(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		facetFields string
+		field       string
+	}{
+		{name: "count is not a number", facetFields: `{"tag_key":["host",1],"tag_value":["host1","x"]}`, field: "tag_value"},
+		{name: "count is a float", facetFields: `{"tag_value":["host1",1.5]}`, field: "tag_value"},
+		{name: "key is an object", facetFields: `{"tag_value":[{"a":1},1]}`, field: "tag_value"},
+		{name: "list is a string", facetFields: `{"tag_key":"host"}`, field: "tag_key"},
+		{name: "facet_fields is an array", facetFields: `["tag_key",["host",1]]`, field: ""},
+	}
+
+	s := &Instance{}
+
+	for _, c := range cases {
+
+		res, err := s.decode(facetResponse(c.facetFields), true, &DefaultDocumentParser{})
+		if !assert.Error(t, err, c.name) {
+			continue
+		}
+
+		assert.Nil(t, res, c.name)
+
+		var facetError *FacetError
+		if assert.True(t, errors.As(err, &facetError), c.name) {
+			assert.Equal(t, c.field, facetError.Field, c.name)
+			assert.NotNil(t, facetError.Unwrap(), c.name)
+		}
+	}
+}
+
+func TestDecodeWithoutFacets(t *testing.T) {
+
+	s := &Instance{}
+
+	res, err := s.decode(facetResponse(`{"tag_value":["host1","x"]}`), false, &DefaultDocumentParser{})
+	if assert.NoError(t, err) {
+		assert.Nil(t, res.Facets)
+	}
+
+	res, err = s.decode([]byte(`{"responseHeader":{"status":0,"QTime":1},"response":{"numFound":0,"start":0,"docs":[]}}`), true, &DefaultDocumentParser{})
+	if assert.NoError(t, err) {
+		assert.Empty(t, res.Facets)
+	}
+}
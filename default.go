@@ -2,6 +2,8 @@ package solr
 
 import (
 	"encoding/json"
+	"io"
+	"reflect"
 )
 
 // DefaultDocumentParser - Default parser
@@ -36,3 +38,11 @@ func (u *DefaultDocumentWriter) Writer(payload interface{}) ([]byte, error) {
 
 	return byte, nil
 }
+
+// Stream - default document stream writer, the documents of a slice are encoded one at a time
+func (u *DefaultDocumentWriter) Stream(output io.Writer, payload interface{}) error {
+
+	return streamJSONArray(output, payload, func(index int, elem reflect.Value) (interface{}, error) {
+		return elem.Interface(), nil
+	})
+}
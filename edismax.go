@@ -0,0 +1,188 @@
+package solr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EDisMaxParams - Params for the extended dismax query parser, for more information visit https://lucene.apache.org/solr/guide/7_4/the-extended-dismax-query-parser.html
+//
+// The fields of QF, PF, PF2 and PF3 are written as field^boost, ex: "title^2.5", PF, PF2 and PF3 also accept a slop, ex: "title~2^10"
+type EDisMaxParams struct {
+	QF    []string //QF - the query fields and their boosts
+	PF    []string //PF - the phrase fields, boosts the documents where all the terms appear close together
+	PF2   []string //PF2 - the bigram phrase fields
+	PF3   []string //PF3 - the trigram phrase fields
+	MM    string   //MM - the minimum should match, ex: "2", "75%", "2<-25% 9<-3"
+	Tie   float64  //Tie - the tie breaker between 0 and 1, the score of the other matching fields multiplied by it is added to the best one
+	BQ    []string //BQ - additive boost queries
+	BF    []string //BF - additive boost functions
+	Boost []string //Boost - multiplicative boost functions
+	UF    []string //UF - the fields the users may query, ex: "*", "-title", "title"
+	PS    int      //PS - the default slop of the phrase fields
+	QS    int      //QS - the slop of the phrases of the query
+}
+
+// fieldBoostRegexp - a field, or a field glob, with an optional boost
+var fieldBoostRegexp = regexp.MustCompile(`^[A-Za-z_*][A-Za-z0-9_.*-]*(\^[0-9]*\.?[0-9]+)?$`)
+
+// phraseFieldRegexp - a field with an optional slop and boost
+var phraseFieldRegexp = regexp.MustCompile(`^[A-Za-z_*][A-Za-z0-9_.*-]*(~[0-9]+)?(\^[0-9]*\.?[0-9]+)?$`)
+
+// Validate - validates the field^boost syntax of the fields and the ranges of the numbers
+func (params *EDisMaxParams) Validate() error {
+
+	if err := validateFields(stringParamQF, params.QF, fieldBoostRegexp); err != nil {
+		return err
+	}
+
+	for _, phraseFields := range []struct {
+		name   string
+		fields []string
+	}{{stringParamPF, params.PF}, {stringParamPF2, params.PF2}, {stringParamPF3, params.PF3}} {
+
+		if err := validateFields(phraseFields.name, phraseFields.fields, phraseFieldRegexp); err != nil {
+			return err
+		}
+	}
+
+	if params.Tie < 0 || params.Tie > 1 {
+		return fmt.Errorf("tie must be between 0 and 1, got %g", params.Tie)
+	}
+
+	if params.PS < 0 || params.QS < 0 {
+		return fmt.Errorf("ps and qs cannot be negative")
+	}
+
+	return nil
+
+}
+
+// validateFields - each value may have more than one field separated by spaces
+func validateFields(param string, values []string, pattern *regexp.Regexp) error {
+
+	for i := 0; i < len(values); i++ {
+
+		fields := strings.Fields(values[i])
+		if len(fields) == 0 {
+			return fmt.Errorf("%s cannot have empty fields", param)
+		}
+
+		for _, field := range fields {
+			if !pattern.MatchString(field) {
+				return fmt.Errorf("invalid %s field: %s", param, field)
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// localParam - a param of the query parser, in the order they are written, param is the name in the query string
+type localParam struct {
+	name  string
+	param string
+	value string
+}
+
+// localParams - the params that are set
+func (params *EDisMaxParams) localParams() []localParam {
+
+	var result []localParam
+
+	fields := func(name, param string, values []string) {
+		if len(values) > 0 {
+			result = append(result, localParam{name, param, strings.Join(strings.Fields(strings.Join(values, stringSpace)), stringSpace)})
+		}
+	}
+
+	fields(stringParamQF, stringQF, params.QF)
+	fields(stringParamPF, stringPF, params.PF)
+	fields(stringParamPF2, stringPF2, params.PF2)
+	fields(stringParamPF3, stringPF3, params.PF3)
+
+	if params.MM != "" {
+		result = append(result, localParam{stringParamMM, stringMM, params.MM})
+	}
+
+	if params.Tie > 0 {
+		result = append(result, localParam{stringParamTie, stringTie, strconv.FormatFloat(params.Tie, 'f', -1, 64)})
+	}
+
+	for i := 0; i < len(params.BQ); i++ {
+		result = append(result, localParam{stringParamBQ, stringBQ, params.BQ[i]})
+	}
+
+	for i := 0; i < len(params.BF); i++ {
+		result = append(result, localParam{stringParamBF, stringBF, params.BF[i]})
+	}
+
+	for i := 0; i < len(params.Boost); i++ {
+		result = append(result, localParam{stringParamBoost, stringBoost, params.Boost[i]})
+	}
+
+	fields(stringParamUF, stringUF, params.UF)
+
+	if params.PS > 0 {
+		result = append(result, localParam{stringParamPS, stringPS, strconv.Itoa(params.PS)})
+	}
+
+	if params.QS > 0 {
+		result = append(result, localParam{stringParamQS, stringQS, strconv.Itoa(params.QS)})
+	}
+
+	return result
+
+}
+
+// writeQueryString - writes the params and defType=edismax, unless the SearchParams has other DefType
+func (params *EDisMaxParams) writeQueryString(qs *strings.Builder, writeDefType bool) {
+
+	if writeDefType {
+		writeParam(qs, stringDefType, edismaxParser)
+	}
+
+	for _, param := range params.localParams() {
+		writeParam(qs, param.param, param.value)
+	}
+
+}
+
+// LocalParams - renders the query with the params as local params, to be used in a filter query or a nested query,
+// ex: {!edismax qf='title^2 body' mm='2' v='solr search'}
+func (params *EDisMaxParams) LocalParams(query string) (string, error) {
+
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	local := strings.Builder{}
+
+	local.WriteString(stringLocalParams)
+	local.WriteString(edismaxParser)
+
+	for _, param := range append(params.localParams(), localParam{name: stringParamV, value: query}) {
+		local.WriteString(stringSpace)
+		local.WriteString(param.name)
+		local.WriteString(stringEqual)
+		local.WriteString(quoteLocalParam(param.value))
+	}
+
+	local.WriteString(stringLocalParamsEnd)
+
+	return local.String(), nil
+
+}
+
+// quoteLocalParam - quotes a local param value, escaping the quotes and backslashes
+func quoteLocalParam(value string) string {
+
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, stringQuote, `\'`)
+
+	return stringQuote + value + stringQuote
+
+}
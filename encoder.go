@@ -0,0 +1,243 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// StructDocumentWriter - writes structs mapped with `solr` tags as solr documents, time.Time fields are written
+// in the solr date format and struct fields are written as child documents, labeled by the field name or anonymous
+// when the field is named _childDocuments_, nil slices, maps and pointers are not written and payloads that are not
+// structs are written as JSON
+type StructDocumentWriter struct {
+}
+
+// Writer - writes the documents as JSON
+func (w *StructDocumentWriter) Writer(payload interface{}) ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+
+	if err := w.Stream(&buffer, payload); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+
+}
+
+// Stream - writes the documents as JSON to the output, the structs of a slice are converted one at a time
+func (w *StructDocumentWriter) Stream(output io.Writer, payload interface{}) error {
+
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || !isDocumentType(value.Type().Elem()) {
+
+		documents, err := MarshalDocuments(payload)
+		if err != nil {
+			return err
+		}
+
+		return json.NewEncoder(output).Encode(documents)
+	}
+
+	return streamJSONArray(output, payload, func(index int, elem reflect.Value) (interface{}, error) {
+		return encodeSliceDocument(index, elem)
+	})
+
+}
+
+// MarshalDocuments - converts a struct, or a slice of structs, mapped with `solr` tags to solr documents
+func MarshalDocuments(payload interface{}) (interface{}, error) {
+
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Kind() == reflect.Struct && value.Type() != timeType:
+
+		return encodeDocument(value)
+
+	case (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && isDocumentType(value.Type().Elem()):
+
+		documents := make([]map[string]interface{}, 0, value.Len())
+
+		for i := 0; i < value.Len(); i++ {
+
+			document, err := encodeSliceDocument(i, value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			documents = append(documents, document)
+		}
+
+		return documents, nil
+
+	default:
+
+		return payload, nil
+	}
+
+}
+
+// encodeSliceDocument - converts the struct, or the pointer to a struct, at the index of a slice
+func encodeSliceDocument(index int, elem reflect.Value) (map[string]interface{}, error) {
+
+	if elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return nil, fmt.Errorf("document %d is nil", index)
+		}
+		elem = elem.Elem()
+	}
+
+	document, err := encodeDocument(elem)
+	if err != nil {
+		return nil, fmt.Errorf("document %d: %v", index, err)
+	}
+
+	return document, nil
+
+}
+
+// isDocumentType - structs other than time.Time are documents
+func isDocumentType(t reflect.Type) bool {
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType
+
+}
+
+// encodeDocument - converts a struct to a solr document
+func encodeDocument(v reflect.Value) (map[string]interface{}, error) {
+
+	info := getStructInfo(v.Type())
+	document := make(map[string]interface{}, len(info.fields))
+
+	for _, field := range info.fields {
+
+		value := v.FieldByIndex(field.index)
+
+		if (field.omitEmpty && isEmptyValue(value)) || isNilValue(value) {
+			continue
+		}
+
+		encoded, err := encodeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", field.name, err)
+		}
+
+		document[field.name] = encoded
+	}
+
+	return document, nil
+
+}
+
+// encodeValue - converts dates to the solr format and structs to documents, other values are written by encoding/json
+func encodeValue(v reflect.Value) (interface{}, error) {
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		return encodeValue(v.Elem())
+
+	case reflect.Slice, reflect.Array:
+
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+
+		elemType := v.Type().Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+
+		if elemType != timeType && !isDocumentType(elemType) {
+			return v.Interface(), nil
+		}
+
+		values := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+
+			var err error
+			if values[i], err = encodeValue(v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+
+	case reflect.Struct:
+
+		if v.Type() == timeType {
+			return FormatDate(v.Interface().(time.Time)), nil
+		}
+
+		return encodeDocument(v)
+
+	default:
+
+		return v.Interface(), nil
+	}
+
+}
+
+// FormatDate - formats the date in the solr format, ex: 2006-01-02T15:04:05.999Z
+func FormatDate(date time.Time) string {
+
+	return date.UTC().Format(solrDateFormat)
+
+}
+
+// isNilValue - nil slices, maps and pointers are left out of the document instead of being written as null
+func isNilValue(v reflect.Value) bool {
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+
+	return false
+
+}
+
+func isEmptyValue(v reflect.Value) bool {
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface().(time.Time).IsZero()
+		}
+	}
+
+	return false
+
+}
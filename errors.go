@@ -0,0 +1,73 @@
+package solr
+
+import (
+	"fmt"
+	"net/http"
+)
+
+//SolrError - an error returned by solr, Code is the solr status
+type SolrError struct {
+	Code    int
+	Message string
+	Trace   string
+}
+
+// Error - the error message
+func (e *SolrError) Error() string {
+
+	if e.Message == "" {
+		return fmt.Sprintf("Solr returned status %d", e.Code)
+	}
+
+	return fmt.Sprintf("Solr returned status %d: %s", e.Code, e.Message)
+
+}
+
+//VersionConflictError - the _version_ of an update does not match the indexed document (status 409)
+type VersionConflictError struct {
+	SolrError
+}
+
+func newSolrError(response *responseRaw) error {
+
+	solrError := SolrError{
+		Code:    response.Header.Status,
+		Message: response.Error.Msg,
+		Trace:   response.Error.Trace,
+	}
+
+	if len(response.Header.Errors) > 0 {
+		return newPartialFailureError(solrError, response)
+	}
+
+	if solrError.Code == http.StatusConflict {
+		return &VersionConflictError{solrError}
+	}
+
+	return &solrError
+
+}
+
+//FacetError - a facet field could not be decoded, Field is empty when the facet_fields section is invalid
+type FacetError struct {
+	Field string
+	Err   error
+}
+
+// Error - the error message
+func (e *FacetError) Error() string {
+
+	if e.Field == "" {
+		return fmt.Sprintf("invalid facet fields: %v", e.Err)
+	}
+
+	return fmt.Sprintf("invalid facet field %s: %v", e.Field, e.Err)
+
+}
+
+// Unwrap - the decoding error
+func (e *FacetError) Unwrap() error {
+
+	return e.Err
+
+}
@@ -0,0 +1,617 @@
+package solr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CSVDocumentWriter - writes the documents as CSV to the /update/csv handler, for more information visit https://lucene.apache.org/solr/guide/7_4/uploading-data-with-index-handlers.html#csv-formatted-index-updates
+type CSVDocumentWriter struct {
+	Fields         []string //Fields - the columns, when empty all fields of the documents are written sorted by name
+	Separator      rune     //Separator - the column separator, default ','
+	SkipHeader     bool     //SkipHeader - does not write the header line, the fields are sent as the fieldnames param
+	SplitFields    []string //SplitFields - the multivalued fields, their values are joined with the SplitSeparator, an array in other fields is an error
+	SplitSeparator rune     //SplitSeparator - the separator of the multivalued values, default '|'
+}
+
+// Writer - writes the documents as CSV, the payload is serialized with encoding/json first so struct json tags are respected
+func (w *CSVDocumentWriter) Writer(payload interface{}) ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+
+	if err := w.Stream(&buffer, payload); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+
+}
+
+// Stream - writes the documents as CSV to the output, one document at a time, without Fields the documents are
+// converted twice, the first pass collects the fields of the header
+func (w *CSVDocumentWriter) Stream(output io.Writer, payload interface{}) error {
+
+	if w.SkipHeader && len(w.Fields) == 0 {
+		return fmt.Errorf("the fields must be defined when the header is skipped")
+	}
+
+	fields := w.Fields
+	if len(fields) == 0 {
+
+		var err error
+		if fields, err = documentFields(payload); err != nil {
+			return err
+		}
+	}
+
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields to write")
+	}
+
+	writer := csv.NewWriter(output)
+	writer.Comma = w.separator()
+
+	if !w.SkipHeader {
+		if err := writer.Write(fields); err != nil {
+			return err
+		}
+	}
+
+	split := w.splitSeparator()
+	record := make([]string, len(fields))
+
+	splitFields := make(map[string]bool, len(w.SplitFields))
+	for i := 0; i < len(w.SplitFields); i++ {
+		splitFields[w.SplitFields[i]] = true
+	}
+
+	err := eachDocumentMap(payload, func(index int, document map[string]interface{}) error {
+
+		for i, field := range fields {
+
+			value, ok := document[field]
+			if !ok || value == nil {
+				record[i] = ""
+				continue
+			}
+
+			if values, ok := value.([]interface{}); ok {
+
+				// solr would index the joined values as a single value
+				if !splitFields[field] {
+					return fmt.Errorf("document %d: the multivalued field %s must be in the SplitFields", index, field)
+				}
+
+				parts := make([]string, len(values))
+				for j := 0; j < len(values); j++ {
+					parts[j] = fmt.Sprint(values[j])
+				}
+
+				record[i] = strings.Join(parts, split)
+				continue
+			}
+
+			if _, ok := value.(map[string]interface{}); ok {
+				return fmt.Errorf("field %s: nested values are not supported by CSV", field)
+			}
+
+			record[i] = fmt.Sprint(value)
+		}
+
+		return writer.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+
+}
+
+// ContentType - the CSV Content-Type
+func (w *CSVDocumentWriter) ContentType() string {
+
+	return contentTypeCSV
+
+}
+
+// Handler - the CSV update handler
+func (w *CSVDocumentWriter) Handler() string {
+
+	return stringUpdateCSV
+
+}
+
+// HandlerParams - the separator, header and split params
+func (w *CSVDocumentWriter) HandlerParams() map[string]string {
+
+	params := map[string]string{}
+
+	if w.Separator != 0 {
+		params[csvSeparator] = string(w.Separator)
+	}
+
+	if w.SkipHeader {
+		params[csvHeader] = stringFalse
+		params[csvFieldNames] = strings.Join(w.Fields, stringComma)
+	} else {
+		params[csvHeader] = stringTrue
+	}
+
+	for i := 0; i < len(w.SplitFields); i++ {
+		params[csvFieldPrefix+w.SplitFields[i]+csvSplitSuffix] = stringTrue
+		params[csvFieldPrefix+w.SplitFields[i]+csvSeparatorSuffix] = w.splitSeparator()
+	}
+
+	return params
+
+}
+
+// documentFields - all fields of the documents sorted by name, the documents are converted one at a time
+func documentFields(payload interface{}) ([]string, error) {
+
+	unique := map[string]bool{}
+
+	err := eachDocumentMap(payload, func(index int, document map[string]interface{}) error {
+
+		for field := range document {
+			unique[field] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(unique))
+	for field := range unique {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return fields, nil
+
+}
+
+func (w *CSVDocumentWriter) separator() rune {
+
+	if w.Separator == 0 {
+		return ','
+	}
+
+	return w.Separator
+
+}
+
+func (w *CSVDocumentWriter) splitSeparator() string {
+
+	if w.SplitSeparator == 0 {
+		return "|"
+	}
+
+	return string(w.SplitSeparator)
+
+}
+
+// XMLDelete - a payload of the XMLDocumentWriter deleting documents by id and by query
+type XMLDelete struct {
+	IDs     []string
+	Queries []string
+}
+
+// XMLDocumentWriter - writes the documents as an XML <add> command, or a <delete> command for XMLDelete payloads,
+// child documents in the _childDocuments_ field are written as nested docs, maps whose keys are all atomic operations
+// (set, add, add-distinct, remove, removeregex, inc) as atomic updates and the other maps as labeled child docs
+// (<field name="label"><doc>...</doc></field>, supported since Solr 8)
+type XMLDocumentWriter struct {
+}
+
+// Writer - writes the documents as XML, the payload is serialized with encoding/json first so struct json tags are respected
+func (w *XMLDocumentWriter) Writer(payload interface{}) ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+
+	if err := w.write(&buffer, payload); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+
+}
+
+// Stream - writes the documents as XML to the output
+func (w *XMLDocumentWriter) Stream(output io.Writer, payload interface{}) error {
+
+	buffer := bufio.NewWriter(output)
+
+	if err := w.write(buffer, payload); err != nil {
+		return err
+	}
+
+	return buffer.Flush()
+
+}
+
+func (w *XMLDocumentWriter) write(buffer textWriter, payload interface{}) error {
+
+	switch p := payload.(type) {
+	case XMLDelete:
+		return w.writeDelete(buffer, &p)
+	case *XMLDelete:
+		return w.writeDelete(buffer, p)
+	}
+
+	buffer.WriteString("<add>")
+
+	err := eachDocumentMap(payload, func(index int, document map[string]interface{}) error {
+		return w.writeDocument(buffer, document)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = buffer.WriteString("</add>")
+
+	return err
+
+}
+
+// ContentType - the XML Content-Type
+func (w *XMLDocumentWriter) ContentType() string {
+
+	return contentTypeXML
+
+}
+
+// Handler - the default update handler
+func (w *XMLDocumentWriter) Handler() string {
+
+	return stringUpdatePath
+
+}
+
+// HandlerParams - no params
+func (w *XMLDocumentWriter) HandlerParams() map[string]string {
+
+	return nil
+
+}
+
+func (w *XMLDocumentWriter) writeDelete(buffer textWriter, payload *XMLDelete) error {
+
+	buffer.WriteString("<delete>")
+
+	for i := 0; i < len(payload.IDs); i++ {
+		buffer.WriteString("<id>")
+		if err := xml.EscapeText(buffer, []byte(payload.IDs[i])); err != nil {
+			return err
+		}
+		buffer.WriteString("</id>")
+	}
+
+	for i := 0; i < len(payload.Queries); i++ {
+		buffer.WriteString("<query>")
+		if err := xml.EscapeText(buffer, []byte(payload.Queries[i])); err != nil {
+			return err
+		}
+		buffer.WriteString("</query>")
+	}
+
+	_, err := buffer.WriteString("</delete>")
+
+	return err
+
+}
+
+func (w *XMLDocumentWriter) writeDocument(buffer textWriter, document map[string]interface{}) error {
+
+	buffer.WriteString("<doc>")
+
+	fields := make([]string, 0, len(document))
+	for field := range document {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+
+		value := document[field]
+
+		if field == rawChildDocuments {
+
+			children, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s must be an array", rawChildDocuments)
+			}
+
+			for _, child := range children {
+
+				childDocument, ok := child.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%s must be an array of documents", rawChildDocuments)
+				}
+
+				if err := w.writeDocument(buffer, childDocument); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if err := w.writeField(buffer, field, "", value); err != nil {
+			return err
+		}
+	}
+
+	buffer.WriteString("</doc>")
+
+	return nil
+
+}
+
+func (w *XMLDocumentWriter) writeField(buffer textWriter, field, update string, value interface{}) error {
+
+	switch v := value.(type) {
+	case []interface{}:
+
+		for i := 0; i < len(v); i++ {
+			if err := w.writeField(buffer, field, update, v[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case map[string]interface{}:
+
+		if update != "" {
+			return fmt.Errorf("field %s: nested values are not supported", field)
+		}
+
+		if !isAtomicOperations(v) {
+			return w.writeLabeledDocument(buffer, field, v)
+		}
+
+		operations := make([]string, 0, len(v))
+		for operation := range v {
+			operations = append(operations, operation)
+		}
+		sort.Strings(operations)
+
+		for _, operation := range operations {
+			if err := w.writeField(buffer, field, operation, v[operation]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	buffer.WriteString(`<field name="`)
+	if err := xml.EscapeText(buffer, []byte(field)); err != nil {
+		return err
+	}
+	buffer.WriteString(`"`)
+
+	if update != "" {
+		buffer.WriteString(` update="`)
+		if err := xml.EscapeText(buffer, []byte(update)); err != nil {
+			return err
+		}
+		buffer.WriteString(`"`)
+	}
+
+	if value == nil {
+		buffer.WriteString(` null="true"/>`)
+		return nil
+	}
+
+	buffer.WriteString(">")
+	if err := xml.EscapeText(buffer, []byte(fmt.Sprint(value))); err != nil {
+		return err
+	}
+	buffer.WriteString("</field>")
+
+	return nil
+
+}
+
+// writeLabeledDocument - a child document in the field of its label
+func (w *XMLDocumentWriter) writeLabeledDocument(buffer textWriter, field string, document map[string]interface{}) error {
+
+	buffer.WriteString(`<field name="`)
+	if err := xml.EscapeText(buffer, []byte(field)); err != nil {
+		return err
+	}
+	buffer.WriteString(`">`)
+
+	if err := w.writeDocument(buffer, document); err != nil {
+		return err
+	}
+
+	buffer.WriteString("</field>")
+
+	return nil
+
+}
+
+// isAtomicOperations - true when all the keys of the map are atomic update operations
+func isAtomicOperations(value map[string]interface{}) bool {
+
+	if len(value) == 0 {
+		return false
+	}
+
+	for operation := range value {
+		switch operation {
+		case atomicSet, atomicAdd, atomicAddDistinct, atomicRemove, atomicRemoveRegex, atomicInc:
+		default:
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// JSONLinesDocumentWriter - writes one JSON document per line to the /update/json/docs handler
+type JSONLinesDocumentWriter struct {
+}
+
+// Writer - writes each element of a slice payload as a JSON line, other payloads are written as a single line
+func (w *JSONLinesDocumentWriter) Writer(payload interface{}) ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+
+	if err := w.Stream(&buffer, payload); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+
+}
+
+// Stream - writes the JSON lines to the output
+func (w *JSONLinesDocumentWriter) Stream(output io.Writer, payload interface{}) error {
+
+	encoder := json.NewEncoder(output)
+
+	value := reflect.ValueOf(payload)
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return encoder.Encode(payload)
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// ContentType - the JSON Content-Type
+func (w *JSONLinesDocumentWriter) ContentType() string {
+
+	return contentType
+
+}
+
+// Handler - the JSON documents update handler
+func (w *JSONLinesDocumentWriter) Handler() string {
+
+	return stringUpdateJSONDocs
+
+}
+
+// HandlerParams - no params
+func (w *JSONLinesDocumentWriter) HandlerParams() map[string]string {
+
+	return nil
+
+}
+
+// textWriter - the output of the XML writer, implemented by bytes.Buffer and bufio.Writer
+type textWriter interface {
+	io.Writer
+	WriteString(s string) (int, error)
+}
+
+// eachDocumentMap - converts a document, or each document of a slice, to a map using encoding/json and calls fn,
+// the documents of a slice are converted one at a time so only the map of the current document is held
+func eachDocumentMap(payload interface{}, fn func(index int, document map[string]interface{}) error) error {
+
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	// a []byte or json.RawMessage payload is already serialized
+	if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || value.Type().Elem().Kind() == reflect.Uint8 {
+
+		decoded, err := decodeDocumentJSON(payload)
+		if err != nil {
+			return err
+		}
+
+		switch v := decoded.(type) {
+		case map[string]interface{}:
+
+			return fn(0, v)
+
+		case []interface{}:
+
+			for i := 0; i < len(v); i++ {
+
+				document, ok := v[i].(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("document %d is not an object", i)
+				}
+
+				if err := fn(i, document); err != nil {
+					return err
+				}
+			}
+
+			return nil
+
+		default:
+
+			return fmt.Errorf("payload must be a document or a list of documents")
+		}
+	}
+
+	for i := 0; i < value.Len(); i++ {
+
+		decoded, err := decodeDocumentJSON(value.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+
+		document, ok := decoded.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("document %d is not an object", i)
+		}
+
+		if err := fn(i, document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// decodeDocumentJSON - serializes the value with encoding/json and decodes it keeping the numbers as json.Number
+func decodeDocumentJSON(value interface{}) (interface{}, error) {
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+
+}
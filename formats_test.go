@@ -0,0 +1,194 @@
+package solr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type formatDocument struct {
+	ID    string   `json:"id"`
+	Value int      `json:"value"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func TestCSVStreamPayloads(t *testing.T) {
+
+	documents := []formatDocument{{ID: "1", Value: 10, Tags: []string{"a", "b"}}, {ID: "2", Value: 20}}
+	expected := "id,tags,value\n1,a|b,10\n2,,20\n"
+
+	payloads := map[string]interface{}{
+		"slice":       documents,
+		"pointer":     &documents,
+		"pointers":    []*formatDocument{&documents[0], &documents[1]},
+		"maps":        []map[string]interface{}{{"id": "1", "value": 10, "tags": []string{"a", "b"}}, {"id": "2", "value": 20}},
+		"raw message": json.RawMessage(`[{"id":"1","value":10,"tags":["a","b"]},{"id":"2","value":20}]`),
+	}
+
+	for name, payload := range payloads {
+
+		buffer := bytes.Buffer{}
+		if assert.NoError(t, (&CSVDocumentWriter{SplitFields: []string{"tags"}}).Stream(&buffer, payload), name) {
+			assert.Equal(t, expected, buffer.String(), name)
+		}
+	}
+
+	buffer := bytes.Buffer{}
+	if assert.NoError(t, (&CSVDocumentWriter{Fields: []string{"value", "id"}, SkipHeader: true}).Stream(&buffer, documents)) {
+		assert.Equal(t, "10,1\n20,2\n", buffer.String())
+	}
+
+	assert.EqualError(t, (&CSVDocumentWriter{SplitFields: []string{"tags"}}).Stream(&buffer, []interface{}{documents[0], "invalid"}), "document 1 is not an object")
+	assert.EqualError(t, (&CSVDocumentWriter{}).Stream(&buffer, documents), "document 0: the multivalued field tags must be in the SplitFields")
+	assert.EqualError(t, (&CSVDocumentWriter{}).Stream(&buffer, []formatDocument{}), "no fields to write")
+}
+
+func TestXMLStreamPayloads(t *testing.T) {
+
+	documents := []formatDocument{{ID: "1", Value: 10, Tags: []string{"a", "b"}}, {ID: "2", Value: 20}}
+
+	buffer := bytes.Buffer{}
+	if !assert.NoError(t, (&XMLDocumentWriter{}).Stream(&buffer, documents)) {
+		return
+	}
+
+	assert.Equal(t, `<add><doc><field name="id">1</field><field name="tags">a</field><field name="tags">b</field><field name="value">10</field></doc>`+
+		`<doc><field name="id">2</field><field name="value">20</field></doc></add>`, buffer.String())
+
+	raw, err := (&XMLDocumentWriter{}).Writer(documents[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, `<add><doc><field name="id">1</field><field name="tags">a</field><field name="tags">b</field><field name="value">10</field></doc></add>`, string(raw))
+	}
+}
+
+func TestXMLLabeledChildrenAndAtomicUpdates(t *testing.T) {
+
+	parent := NewNestedDocument(DocumentRaw{"id": "1"}).
+		AddLabeledChildren("comments", NewNestedDocument(DocumentRaw{"id": "1-1"}), NewNestedDocument(DocumentRaw{"id": "1-2"})).
+		AddChildren(NewNestedDocument(DocumentRaw{"id": "1-3"}))
+
+	raw, err := (&XMLDocumentWriter{}).Writer(parent)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `<add><doc><doc><field name="id">1-3</field></doc><field name="comments"><doc><field name="id">1-1</field></doc></field>`+
+			`<field name="comments"><doc><field name="id">1-2</field></doc></field><field name="id">1</field></doc></add>`, string(raw))
+	}
+
+	raw, err = (&XMLDocumentWriter{}).Writer(map[string]interface{}{"id": "1", "tags": map[string]interface{}{"add": "a", "remove": "b"}, "count": map[string]interface{}{"inc": 1}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, `<add><doc><field name="count" update="inc">1</field><field name="id">1</field>`+
+			`<field name="tags" update="add">a</field><field name="tags" update="remove">b</field></doc></add>`, string(raw))
+	}
+
+	raw, err = (&XMLDocumentWriter{}).Writer(map[string]interface{}{"id": "1", "author": map[string]interface{}{"id": "2", "set": "x"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, `<add><doc><field name="author"><doc><field name="id">2</field><field name="set">x</field></doc></field><field name="id">1</field></doc></add>`, string(raw))
+	}
+}
+
+func TestStreamGzipLevels(t *testing.T) {
+
+	s := &Instance{documentWriter: &JSONLinesDocumentWriter{}}
+	documents := []formatDocument{{ID: "1", Value: 10}, {ID: "2", Value: 20}}
+
+	for _, level := range []int{gzip.DefaultCompression, gzip.NoCompression, gzip.BestCompression} {
+
+		buffer := bytes.Buffer{}
+		if !assert.NoError(t, s.streamDocuments(&buffer, documents, true, level)) {
+			continue
+		}
+
+		reader, err := gzip.NewReader(&buffer)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		raw, err := ioutil.ReadAll(reader)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "{\"id\":\"1\",\"value\":10}\n{\"id\":\"2\",\"value\":20}\n", string(raw))
+		}
+	}
+}
+
+func TestStreamOptionsGzipLevel(t *testing.T) {
+
+	level, err := (&StreamOptions{Gzip: true}).gzipLevel()
+	if assert.NoError(t, err) {
+		assert.Equal(t, gzip.DefaultCompression, level)
+	}
+
+	for _, option := range []int{gzip.NoCompression, gzip.BestSpeed, gzip.BestCompression, gzip.DefaultCompression, gzip.HuffmanOnly} {
+
+		level, err := (&StreamOptions{Gzip: true, GzipLevel: &option}).gzipLevel()
+		if assert.NoError(t, err) {
+			assert.Equal(t, option, level)
+		}
+	}
+
+	invalid := 10
+	_, err = (&StreamOptions{Gzip: true, GzipLevel: &invalid}).gzipLevel()
+	assert.EqualError(t, err, "invalid gzip level: 10")
+}
+
+// streamProbe - a field value that waits until the previous documents reach the reader before it is encoded
+type streamProbe chan struct{}
+
+func (p streamProbe) MarshalJSON() ([]byte, error) {
+
+	select {
+	case <-p:
+		return []byte(stringTrue), nil
+	case <-time.After(time.Second):
+		return nil, fmt.Errorf("no bytes written before the last document")
+	}
+}
+
+func TestJSONStreamWritesEachDocument(t *testing.T) {
+
+	type structDocument struct {
+		ID    string       `solr:"id"`
+		Probe *streamProbe `solr:"probe"`
+	}
+
+	payloads := map[string]func(received streamProbe) (StreamDocumentWriter, interface{}){
+		"default": func(received streamProbe) (StreamDocumentWriter, interface{}) {
+			return &DefaultDocumentWriter{}, []map[string]interface{}{{"id": "1"}, {"id": "2", "probe": received}}
+		},
+		"nested": func(received streamProbe) (StreamDocumentWriter, interface{}) {
+			return &NestedDocumentWriter{}, []*NestedDocument{
+				NewNestedDocument(DocumentRaw{"id": "1"}),
+				NewNestedDocument(DocumentRaw{"id": "2", "probe": received}),
+			}
+		},
+		"struct": func(received streamProbe) (StreamDocumentWriter, interface{}) {
+			return &StructDocumentWriter{}, []structDocument{{ID: "1"}, {ID: "2", Probe: &received}}
+		},
+	}
+
+	for name, payload := range payloads {
+
+		received := make(streamProbe)
+		writer, documents := payload(received)
+
+		reader, output := io.Pipe()
+
+		go func() {
+			output.CloseWithError(writer.Stream(output, documents))
+		}()
+
+		buffer := make([]byte, 1)
+		_, err := io.ReadFull(reader, buffer)
+		close(received)
+
+		rest, readErr := ioutil.ReadAll(reader)
+		if assert.NoError(t, err, name) && assert.NoError(t, readErr, name) {
+			assert.True(t, json.Valid(append(buffer, rest...)), name)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package solr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Function - a function query, for more information visit https://lucene.apache.org/solr/guide/7_4/function-queries.html
+//
+// It can be used as a sort (Asc, Desc), a fl pseudo-field (As), a {!frange} filter (Frange) and, as a string,
+// in the EDisMaxParams Boost and BF, ex: the recency boost Recip(MsDiff(Now().Function(), FieldName("creation_date")), 3.16e-11, 1, 1)
+type Function string
+
+// call - renders the function with its arguments
+func call(name string, args ...Function) Function {
+
+	call := strings.Builder{}
+
+	call.WriteString(name)
+	call.WriteString(stringParenthesis)
+
+	for i := 0; i < len(args); i++ {
+		if i > 0 {
+			call.WriteString(stringComma)
+		}
+		call.WriteString(string(args[i]))
+	}
+
+	call.WriteString(stringParenthesisEnd)
+
+	return Function(call.String())
+
+}
+
+// Field - the value of a field, ex: field(creation_date)
+func Field(name string) Function {
+
+	return call(funcField, Function(name))
+
+}
+
+// FieldName - the bare name of a field, the argument of the functions that do not accept field(), ex: ms(NOW,creation_date)
+func FieldName(name string) Function {
+
+	return Function(name)
+
+}
+
+// Value - a constant, ex: 3.16e-11
+func Value(value float64) Function {
+
+	return Function(formatFloat(value))
+
+}
+
+// Recip - the reciprocal m*x+b: a/(m*x+b), ex: recip(ms(NOW,creation_date),3.16e-11,1,1)
+func Recip(x Function, m, a, b float64) Function {
+
+	return call(funcRecip, x, Value(m), Value(a), Value(b))
+
+}
+
+// Ms - the milliseconds since the epoch of a FieldName or a date math, ex: ms(NOW/DAY)
+func Ms(date Function) Function {
+
+	return call(funcMs, date)
+
+}
+
+// MsDiff - the milliseconds between two dates, each a FieldName or a date math, ex: ms(NOW,creation_date)
+func MsDiff(a, b Function) Function {
+
+	return call(funcMs, a, b)
+
+}
+
+// Log - the base 10 logarithm, ex: log(sum(popularity,1))
+func Log(x Function) Function {
+
+	return call(funcLog, x)
+
+}
+
+// Sum - the sum of the values
+func Sum(values ...Function) Function {
+
+	return call(funcSum, values...)
+
+}
+
+// Product - the product of the values
+func Product(values ...Function) Function {
+
+	return call(funcProduct, values...)
+
+}
+
+// If - the trueValue when the condition is true (or not zero), otherwise the falseValue
+func If(condition, trueValue, falseValue Function) Function {
+
+	return call(funcIf, condition, trueValue, falseValue)
+
+}
+
+// Exists - true when the field, or the function, has a value
+func Exists(value Function) Function {
+
+	return call(funcExists, value)
+
+}
+
+// Query - the score of the query, zero for the documents that do not match, ex: query({!v='type:meta'})
+func Query(query string) Function {
+
+	return call(funcQuery, Function(stringLocalParams+stringParamV+stringEqual+quoteLocalParam(query)+stringLocalParamsEnd))
+
+}
+
+// String - the function query
+func (f Function) String() string {
+
+	return string(f)
+
+}
+
+// Asc - the ascending sort by the function
+func (f Function) Asc() string {
+
+	return string(f) + stringSpace + sortAsc
+
+}
+
+// Desc - the descending sort by the function
+func (f Function) Desc() string {
+
+	return string(f) + stringSpace + sortDesc
+
+}
+
+// As - a fl pseudo-field with the value of the function, ex: age:ms(NOW,creation_date)
+func (f Function) As(alias string) string {
+
+	return alias + stringColon + string(f)
+
+}
+
+// Frange - a filter query matching the documents where the function is in the inclusive range, an infinite bound is not written,
+// ex: {!frange l=0 u=2.5}log(popularity)
+func (f Function) Frange(lower, upper float64) string {
+
+	frange := strings.Builder{}
+
+	frange.WriteString(stringLocalParams)
+	frange.WriteString(funcFrange)
+
+	if !math.IsInf(lower, 0) {
+		frange.WriteString(stringSpace + stringFrangeLower + stringEqual)
+		frange.WriteString(formatFloat(lower))
+	}
+
+	if !math.IsInf(upper, 0) {
+		frange.WriteString(stringSpace + stringFrangeUpper + stringEqual)
+		frange.WriteString(formatFloat(upper))
+	}
+
+	frange.WriteString(stringLocalParamsEnd)
+	frange.WriteString(string(f))
+
+	return frange.String()
+
+}
+
+func formatFloat(value float64) string {
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+
+}
+
+// DateUnit - a unit of the solr date math
+type DateUnit string
+
+const (
+	// DateYear - YEAR
+	DateYear DateUnit = "YEAR"
+	// DateMonth - MONTH
+	DateMonth DateUnit = "MONTH"
+	// DateDay - DAY
+	DateDay DateUnit = "DAY"
+	// DateHour - HOUR
+	DateHour DateUnit = "HOUR"
+	// DateMinute - MINUTE
+	DateMinute DateUnit = "MINUTE"
+	// DateSecond - SECOND
+	DateSecond DateUnit = "SECOND"
+	// DateMilli - MILLI
+	DateMilli DateUnit = "MILLI"
+)
+
+// DateMath - a solr date math expression, ex: NOW/DAY-1MONTH, for more information visit https://lucene.apache.org/solr/guide/7_4/working-with-dates.html#date-math
+type DateMath string
+
+// Now - the current time
+func Now() DateMath {
+
+	return DateMath(dateNow)
+
+}
+
+// Date - a fixed date in the solr format
+func Date(date time.Time) DateMath {
+
+	return DateMath(FormatDate(date))
+
+}
+
+// Round - rounds down to the unit, ex: Now().Round(DateDay) is NOW/DAY
+func (d DateMath) Round(unit DateUnit) DateMath {
+
+	return d + DateMath(stringBar) + DateMath(unit)
+
+}
+
+// Add - adds the amount of units, negative amounts subtract, ex: Now().Round(DateDay).Add(-1, DateMonth) is NOW/DAY-1MONTH
+func (d DateMath) Add(amount int, unit DateUnit) DateMath {
+
+	if amount >= 0 {
+		d += DateMath(stringPlus)
+	}
+
+	return d + DateMath(strconv.Itoa(amount)) + DateMath(unit)
+
+}
+
+// String - the date math expression
+func (d DateMath) String() string {
+
+	return string(d)
+
+}
+
+// Function - the date math as a function argument, ex: Ms(Now().Round(DateDay).Function())
+func (d DateMath) Function() Function {
+
+	return Function(d)
+
+}
+
+// DateRange - a range query on the date field, ex: creation_date:[NOW/DAY-1MONTH TO NOW]
+func DateRange(field string, from, to DateMath) string {
+
+	return field + stringColon + stringRangeStart + string(from) + stringRangeTo + string(to) + stringRangeEnd
+
+}
@@ -0,0 +1,205 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// GetParams - Params for real-time get queries, for more information visit https://lucene.apache.org/solr/guide/7_4/realtime-get.html
+type GetParams struct {
+	FL            string //FL - the fields to return, the id is always added to match the documents to the requested ids
+	FilterQueries []string
+}
+
+//GetResponse - response data from a real-time get, Versions maps each found id to its _version_ when the field is returned
+type GetResponse struct {
+	NumFound int64
+	Docs     interface{}
+	Versions map[string]int64
+	Missing  []string
+}
+
+// Found - returns false if the requested id is missing
+func (r *GetResponse) Found(id string) bool {
+
+	for i := 0; i < len(r.Missing); i++ {
+		if r.Missing[i] == id {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+func (params *GetParams) toQueryString(ids []string) string {
+
+	qs := strings.Builder{}
+
+	for i := 0; i < len(ids); i++ {
+		writeParam(&qs, stringID, ids[i])
+	}
+
+	if params == nil {
+		return qs.String()
+	}
+
+	for i := 0; i < len(params.FilterQueries); i++ {
+		writeParam(&qs, stringFQ, params.FilterQueries[i])
+	}
+
+	if params.FL != "" {
+		writeParam(&qs, stringFL, withIDField(params.FL))
+	}
+
+	return qs.String()
+
+}
+
+// withIDField - adds the id to the field list, unless it is already returned
+func withIDField(fl string) string {
+
+	fields := strings.FieldsFunc(fl, func(c rune) bool {
+		return c == ',' || c == ' '
+	})
+
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == rawID || fields[i] == stringWildcard {
+			return fl
+		}
+	}
+
+	return fl + stringComma + rawID
+
+}
+
+// Get - retrieves the latest version of the documents by id, including the uncommitted ones
+func (s *Instance) Get(instanceName string, ids ...string) (*GetResponse, error) {
+
+	return s.GetWithParams(instanceName, nil, ids...)
+
+}
+
+// GetWithParams - real-time get with fields and filter queries, the ids not found are listed in GetResponse.Missing
+func (s *Instance) GetWithParams(instanceName string, params *GetParams, ids ...string) (*GetResponse, error) {
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one id must be defined")
+	}
+
+	raw, err := s.httpGet(s.getURL(instanceName, params, ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decodeGet(raw, ids)
+
+}
+
+func (s *Instance) getURL(instanceName string, params *GetParams, ids []string) string {
+
+	stringParams := params.toQueryString(ids)
+
+	url := strings.Builder{}
+
+	url.Grow(len(s.coreURL) + len(stringBar)*3 + len(stringGet) + len(stringSolrBase) + len(instanceName) + len(stringParams))
+
+	url.WriteString(s.coreURL)
+	url.WriteString(stringBar)
+	url.WriteString(stringSolrBase)
+	url.WriteString(stringBar)
+	url.WriteString(instanceName)
+	url.WriteString(stringBar)
+	url.WriteString(stringGet)
+	url.WriteString(stringParams)
+
+	return url.String()
+
+}
+
+func (s *Instance) decodeGet(raw []byte, ids []string) (*GetResponse, error) {
+
+	var documents []byte
+
+	if value, dataType, _, err := jsonparser.Get(raw, rawDoc); err == nil {
+
+		// a single id returns the document itself instead of a document list
+		if dataType == jsonparser.Null {
+			documents = []byte(rawEmptyDocumentList)
+		} else {
+			documents = []byte(rawSingleDocumentListPrefix + string(value) + rawSingleDocumentListSuffix)
+		}
+
+	} else if err != jsonparser.KeyPathNotFoundError {
+
+		return nil, fmt.Errorf("error parsing doc: %v", err.Error())
+
+	} else if documents, _, _, err = jsonparser.Get(raw, rawResponse); err != nil {
+
+		return nil, fmt.Errorf("error parsing response: %v", err.Error())
+	}
+
+	res := &GetResponse{
+		Versions: map[string]int64{},
+	}
+
+	var err error
+
+	if res.NumFound, err = jsonparser.GetInt(documents, rawNumFound); err != nil {
+		return nil, fmt.Errorf("error parsing numbers: %v", err.Error())
+	}
+
+	if res.Docs, err = s.parseDocumentList(documents); err != nil {
+		return nil, fmt.Errorf("error parsing docs: %v", err.Error())
+	}
+
+	found := map[string]bool{}
+	var parseError error
+
+	_, err = jsonparser.ArrayEach(documents, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+		if parseError != nil {
+			return
+		}
+
+		id, idType, _, err := jsonparser.Get(value, rawID)
+		if err == jsonparser.KeyPathNotFoundError {
+			// the documents without id cannot be matched, ex: the id was removed by a transformer
+			return
+		} else if err != nil {
+			parseError = err
+			return
+		}
+
+		key := string(id)
+		if idType == jsonparser.String {
+			if key, err = jsonparser.ParseString(id); err != nil {
+				parseError = err
+				return
+			}
+		}
+
+		found[key] = true
+
+		if version, err := jsonparser.GetInt(value, rawVersion); err == nil {
+			res.Versions[key] = version
+		}
+	}, rawDocs)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing docs: %v", err.Error())
+	}
+	if parseError != nil {
+		return nil, fmt.Errorf("error parsing ids: %v", parseError.Error())
+	}
+
+	for i := 0; i < len(ids); i++ {
+		if !found[ids[i]] {
+			res.Missing = append(res.Missing, ids[i])
+		}
+	}
+
+	return res, nil
+
+}
@@ -0,0 +1,38 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQueryStringAddsID(t *testing.T) {
+
+	cases := map[string]string{
+		"metric":           "&id=1&fl=metric%2Cid",
+		"metric,id":        "&id=1&fl=metric%2Cid",
+		"id metric":        "&id=1&fl=id+metric",
+		"*":                "&id=1&fl=%2A",
+		"metric,_version_": "&id=1&fl=metric%2C_version_%2Cid",
+	}
+
+	for fl, expected := range cases {
+		assert.Equal(t, expected, (&GetParams{FL: fl}).toQueryString([]string{"1"}), fl)
+	}
+
+}
+
+func TestDecodeGetWithoutID(t *testing.T) {
+
+	s := &Instance{documentParser: &DefaultDocumentParser{}}
+
+	res, err := s.decodeGet([]byte(`{"response":{"numFound":2,"start":0,"docs":[{"id":"1","metric":"a"},{"metric":"b"}]}}`), []string{"1", "2", "3"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(2), res.NumFound)
+	assert.True(t, res.Found("1"))
+	assert.Equal(t, []string{"2", "3"}, res.Missing)
+
+}
@@ -1,5 +1,7 @@
 package solr
 
+import "io"
+
 // DocumentWriter - a generic document writer
 type DocumentWriter interface {
 
@@ -13,3 +15,34 @@ type DocumentParser interface {
 	// Parse - parses the pure document input from JSON
 	Parse(documents []byte) (interface{}, error)
 }
+
+// UpdateHandlerWriter - a DocumentWriter for other update formats, UpdateDocument posts the
+// written documents to its handler using its Content-Type instead of application/json
+type UpdateHandlerWriter interface {
+	DocumentWriter
+
+	// ContentType - the Content-Type of the written documents
+	ContentType() string
+
+	// Handler - the update handler path, ex: /update/csv
+	Handler() string
+
+	// HandlerParams - the params of the update handler, merged with the postParams
+	HandlerParams() map[string]string
+}
+
+// StreamDocumentWriter - a DocumentWriter able to write the documents directly to an io.Writer,
+// used by UpdateDocumentStream to avoid materializing large payloads in memory
+type StreamDocumentWriter interface {
+	DocumentWriter
+
+	// Stream - writes the documents to the output
+	Stream(output io.Writer, payload interface{}) error
+}
+
+// DeadLetterSink - stores the documents that failed to be indexed, it must be safe for concurrent use
+type DeadLetterSink interface {
+
+	// Write - stores a rejected document
+	Write(letter *DeadLetter) error
+}
@@ -0,0 +1,843 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// The javabin tags, for more information visit https://github.com/apache/lucene-solr/blob/branch_7_4/solr/solrj/src/java/org/apache/solr/common/util/JavaBinCodec.java
+const (
+	javabinVersion byte = 2
+
+	javabinNull          byte = 0
+	javabinTrue          byte = 1
+	javabinFalse         byte = 2
+	javabinByte          byte = 3
+	javabinShort         byte = 4
+	javabinDouble        byte = 5
+	javabinInt           byte = 6
+	javabinLong          byte = 7
+	javabinFloat         byte = 8
+	javabinDate          byte = 9
+	javabinMap           byte = 10
+	javabinSolrDoc       byte = 11
+	javabinSolrDocList   byte = 12
+	javabinByteArray     byte = 13
+	javabinIterator      byte = 14
+	javabinEnd           byte = 15
+	javabinSolrInputDoc  byte = 16
+	javabinMapEntryIter  byte = 17
+	javabinEnumField     byte = 18
+	javabinMapEntry      byte = 19
+	javabinUUID          byte = 20
+	javabinString        byte = 1 << 5
+	javabinSmallInt      byte = 2 << 5
+	javabinSmallLong     byte = 3 << 5
+	javabinArray         byte = 4 << 5
+	javabinOrderedMap    byte = 5 << 5
+	javabinNamedList     byte = 6 << 5
+	javabinExternString  byte = 7 << 5
+	javabinTagTypeMask   byte = 7 << 5
+	javabinTagSizeMask   byte = 0x1f
+	javabinSmallMask     byte = 0x0f
+	javabinSmallContinue byte = 0x10
+)
+
+// ResponseFormat - the response format requested by Search
+type ResponseFormat int
+
+const (
+	// FormatJSON - wt=json, the default
+	FormatJSON ResponseFormat = iota
+	// FormatJavabin - wt=javabin, the native solr binary format
+	FormatJavabin
+)
+
+// SetResponseFormat - sets the format of the Search responses, both are decoded into the same Response
+func (s *Instance) SetResponseFormat(format ResponseFormat) {
+
+	s.responseFormat = format
+
+}
+
+//NamedList - a solr named list, the names may repeat and the order is kept, a null name (ex: the facet.missing count) is empty
+type NamedList struct {
+	Names   []string
+	Values  []interface{}
+	Ordered bool //Ordered - a SimpleOrderedMap, written as a JSON object instead of a flat array
+	nulls   map[int]bool
+}
+
+// Get - the first value of the name
+func (l *NamedList) Get(name string) (interface{}, bool) {
+
+	for i := 0; i < len(l.Names); i++ {
+		if l.Names[i] == name {
+			return l.Values[i], true
+		}
+	}
+
+	return nil, false
+
+}
+
+// MarshalJSON - writes the list as the solr JSON response writer does, an object when ordered and a flat array otherwise
+func (l *NamedList) MarshalJSON() ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+
+	if l.Ordered {
+		buffer.WriteByte('{')
+	} else {
+		buffer.WriteByte('[')
+	}
+
+	for i := 0; i < len(l.Names); i++ {
+
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name := []byte(stringNull)
+		if !l.nulls[i] || l.Ordered {
+
+			var err error
+			if name, err = json.Marshal(l.Names[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		value, err := json.Marshal(l.Values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		if l.Ordered {
+			buffer.WriteByte(':')
+		} else {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(value)
+	}
+
+	if l.Ordered {
+		buffer.WriteByte('}')
+	} else {
+		buffer.WriteByte(']')
+	}
+
+	return buffer.Bytes(), nil
+
+}
+
+//DocumentList - a solr document list
+type DocumentList struct {
+	NumFound int64         `json:"numFound"`
+	Start    int64         `json:"start"`
+	MaxScore *float32      `json:"maxScore,omitempty"`
+	Docs     []DocumentRaw `json:"docs"`
+}
+
+// UnmarshalJavabin - decodes a javabin payload, the values are: nil, bool, int8, int16, int32, int64, float32, float64,
+// string, time.Time, []byte, []interface{}, map[string]interface{}, *NamedList, DocumentRaw and *DocumentList,
+// the child documents are in the _childDocuments_ field of the DocumentRaw
+func UnmarshalJavabin(raw []byte) (interface{}, error) {
+
+	if len(raw) == 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if raw[0] != javabinVersion {
+		return nil, fmt.Errorf("unsupported javabin version: %d", raw[0])
+	}
+
+	decoder := &javabinDecoder{raw: raw, position: 1}
+
+	return decoder.readValue()
+
+}
+
+// javabinEndMarker - the end of an iterator
+type javabinEndMarker struct{}
+
+type javabinDecoder struct {
+	raw      []byte
+	position int
+	strings  []string
+}
+
+func (d *javabinDecoder) readByte() (byte, error) {
+
+	if d.position >= len(d.raw) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	b := d.raw[d.position]
+	d.position++
+
+	return b, nil
+
+}
+
+func (d *javabinDecoder) readBytes(size int) ([]byte, error) {
+
+	if size < 0 || d.position+size > len(d.raw) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	b := d.raw[d.position : d.position+size]
+	d.position += size
+
+	return b, nil
+
+}
+
+func (d *javabinDecoder) readVInt() (int, error) {
+
+	var value int
+
+	for shift := uint(0); shift < 35; shift += 7 {
+
+		b, err := d.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= int(b&0x7f) << shift
+
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("javabin vint overflow")
+
+}
+
+func (d *javabinDecoder) readVLong() (int64, error) {
+
+	var value int64
+
+	for shift := uint(0); shift < 70; shift += 7 {
+
+		b, err := d.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= int64(b&0x7f) << shift
+
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("javabin vlong overflow")
+
+}
+
+// readSize - the size in the lower 5 bits of the tag, followed by a vint when it does not fit
+func (d *javabinDecoder) readSize(tag byte) (int, error) {
+
+	size := int(tag & javabinTagSizeMask)
+	if size != int(javabinTagSizeMask) {
+		return size, nil
+	}
+
+	more, err := d.readVInt()
+	if err != nil {
+		return 0, err
+	}
+
+	return size + more, nil
+
+}
+
+func (d *javabinDecoder) readFixed(size int) (uint64, error) {
+
+	b, err := d.readBytes(size)
+	if err != nil {
+		return 0, err
+	}
+
+	switch size {
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+
+}
+
+func (d *javabinDecoder) readValue() (interface{}, error) {
+
+	tag, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag & javabinTagTypeMask {
+	case javabinString:
+		return d.readString(tag)
+	case javabinSmallInt:
+		return d.readSmallInt(tag)
+	case javabinSmallLong:
+		return d.readSmallLong(tag)
+	case javabinArray:
+		return d.readArray(tag)
+	case javabinOrderedMap:
+		return d.readNamedList(tag, true)
+	case javabinNamedList:
+		return d.readNamedList(tag, false)
+	case javabinExternString:
+		return d.readExternString(tag)
+	}
+
+	switch tag {
+	case javabinNull:
+		return nil, nil
+	case javabinTrue:
+		return true, nil
+	case javabinFalse:
+		return false, nil
+	case javabinByte:
+		b, err := d.readByte()
+		return int8(b), err
+	case javabinShort:
+		v, err := d.readFixed(2)
+		return int16(v), err
+	case javabinInt:
+		v, err := d.readFixed(4)
+		return int32(v), err
+	case javabinLong:
+		v, err := d.readFixed(8)
+		return int64(v), err
+	case javabinFloat:
+		v, err := d.readFixed(4)
+		return math.Float32frombits(uint32(v)), err
+	case javabinDouble:
+		v, err := d.readFixed(8)
+		return math.Float64frombits(v), err
+	case javabinDate:
+		v, err := d.readFixed(8)
+		ms := int64(v)
+		return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC(), err
+	case javabinMap:
+		return d.readMap()
+	case javabinSolrDoc:
+		return d.readDocument()
+	case javabinSolrDocList:
+		return d.readDocumentList()
+	case javabinByteArray:
+		return d.readByteArray()
+	case javabinIterator:
+		return d.readIterator()
+	case javabinEnd:
+		return javabinEndMarker{}, nil
+	case javabinMapEntryIter:
+		return d.readMapEntryIterator()
+	case javabinEnumField:
+		return d.readEnumField()
+	case javabinMapEntry:
+		return d.readMapEntry()
+	case javabinUUID:
+		return d.readUUID()
+	default:
+		return nil, fmt.Errorf("unsupported javabin tag %d at %d", tag, d.position-1)
+	}
+
+}
+
+func (d *javabinDecoder) readString(tag byte) (string, error) {
+
+	size, err := d.readSize(tag)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := d.readBytes(size)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+
+}
+
+// readExternString - a string written once and referenced by its index in the next occurrences
+func (d *javabinDecoder) readExternString(tag byte) (string, error) {
+
+	index, err := d.readSize(tag)
+	if err != nil {
+		return "", err
+	}
+
+	if index > 0 {
+
+		if index > len(d.strings) {
+			return "", fmt.Errorf("invalid javabin extern string %d", index)
+		}
+
+		return d.strings[index-1], nil
+	}
+
+	value, err := d.readValue()
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid javabin extern string type %T", value)
+	}
+
+	d.strings = append(d.strings, s)
+
+	return s, nil
+
+}
+
+func (d *javabinDecoder) readSmallInt(tag byte) (int32, error) {
+
+	value := int32(tag & javabinSmallMask)
+
+	if tag&javabinSmallContinue != 0 {
+
+		more, err := d.readVInt()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= int32(more) << 4
+	}
+
+	return value, nil
+
+}
+
+func (d *javabinDecoder) readSmallLong(tag byte) (int64, error) {
+
+	value := int64(tag & javabinSmallMask)
+
+	if tag&javabinSmallContinue != 0 {
+
+		more, err := d.readVLong()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= more << 4
+	}
+
+	return value, nil
+
+}
+
+func (d *javabinDecoder) readArray(tag byte) ([]interface{}, error) {
+
+	size, err := d.readSize(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.readValues(size)
+
+}
+
+func (d *javabinDecoder) readValues(size int) ([]interface{}, error) {
+
+	if size > len(d.raw)-d.position {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	values := make([]interface{}, size)
+
+	for i := 0; i < size; i++ {
+
+		var err error
+		if values[i], err = d.readValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+
+}
+
+func (d *javabinDecoder) readNamedList(tag byte, ordered bool) (*NamedList, error) {
+
+	size, err := d.readSize(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > len(d.raw)-d.position {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	list := &NamedList{
+		Names:   make([]string, size),
+		Values:  make([]interface{}, size),
+		Ordered: ordered,
+	}
+
+	for i := 0; i < size; i++ {
+
+		name, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if name != nil {
+			list.Names[i] = fmt.Sprint(name)
+		} else {
+
+			if list.nulls == nil {
+				list.nulls = map[int]bool{}
+			}
+
+			list.nulls[i] = true
+		}
+
+		if list.Values[i], err = d.readValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+
+}
+
+func (d *javabinDecoder) readMap() (map[string]interface{}, error) {
+
+	size, err := d.readVInt()
+	if err != nil {
+		return nil, err
+	}
+
+	if size > len(d.raw)-d.position {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	values := make(map[string]interface{}, size)
+
+	for i := 0; i < size; i++ {
+
+		key, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		values[fmt.Sprint(key)] = value
+	}
+
+	return values, nil
+
+}
+
+// readDocument - the size is written as an ordered map tag and the child documents have no field name
+func (d *javabinDecoder) readDocument() (DocumentRaw, error) {
+
+	tag, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := d.readSize(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > len(d.raw)-d.position {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	document := make(DocumentRaw, size)
+
+	for i := 0; i < size; i++ {
+
+		name, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if child, ok := name.(DocumentRaw); ok {
+
+			children, _ := document[rawChildDocuments].([]interface{})
+			document[rawChildDocuments] = append(children, child)
+			continue
+		}
+
+		field, ok := name.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid javabin field name type %T", name)
+		}
+
+		if document[field], err = d.readValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return document, nil
+
+}
+
+func (d *javabinDecoder) readDocumentList() (*DocumentList, error) {
+
+	header, err := d.readValue()
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := header.([]interface{})
+	if !ok || len(values) < 3 {
+		return nil, fmt.Errorf("invalid javabin document list header")
+	}
+
+	list := &DocumentList{}
+
+	if list.NumFound, ok = values[0].(int64); !ok {
+		return nil, fmt.Errorf("invalid javabin numFound type %T", values[0])
+	}
+
+	if list.Start, ok = values[1].(int64); !ok {
+		return nil, fmt.Errorf("invalid javabin start type %T", values[1])
+	}
+
+	if maxScore, ok := values[2].(float32); ok {
+		list.MaxScore = &maxScore
+	}
+
+	documents, err := d.readValue()
+	if err != nil {
+		return nil, err
+	}
+
+	docs, ok := documents.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid javabin document list type %T", documents)
+	}
+
+	list.Docs = make([]DocumentRaw, len(docs))
+
+	for i := 0; i < len(docs); i++ {
+		if list.Docs[i], ok = docs[i].(DocumentRaw); !ok {
+			return nil, fmt.Errorf("invalid javabin document type %T", docs[i])
+		}
+	}
+
+	return list, nil
+
+}
+
+func (d *javabinDecoder) readByteArray() ([]byte, error) {
+
+	size, err := d.readVInt()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := d.readBytes(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), b...), nil
+
+}
+
+func (d *javabinDecoder) readIterator() ([]interface{}, error) {
+
+	var values []interface{}
+
+	for {
+
+		value, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := value.(javabinEndMarker); ok {
+			return values, nil
+		}
+
+		values = append(values, value)
+	}
+
+}
+
+func (d *javabinDecoder) readMapEntryIterator() (*NamedList, error) {
+
+	list := &NamedList{Ordered: true}
+
+	for {
+
+		key, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := key.(javabinEndMarker); ok {
+			return list, nil
+		}
+
+		value, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		list.Names = append(list.Names, fmt.Sprint(key))
+		list.Values = append(list.Values, value)
+	}
+
+}
+
+// readEnumField - an enum field is written as its int value followed by its string value
+func (d *javabinDecoder) readEnumField() (string, error) {
+
+	if _, err := d.readValue(); err != nil {
+		return "", err
+	}
+
+	value, err := d.readValue()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprint(value), nil
+
+}
+
+func (d *javabinDecoder) readMapEntry() (*NamedList, error) {
+
+	key, err := d.readValue()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := d.readValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return &NamedList{Names: []string{fmt.Sprint(key)}, Values: []interface{}{value}, Ordered: true}, nil
+
+}
+
+func (d *javabinDecoder) readUUID() (string, error) {
+
+	b, err := d.readBytes(16)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+
+}
+
+// toJSONValue - converts a document value to the type decoded from the JSON format:
+// numbers to float64, dates to RFC3339 strings, byte arrays to base64 strings and the named lists
+// to objects when ordered, or flat arrays of names and values otherwise
+func toJSONValue(value interface{}) interface{} {
+
+	switch v := value.(type) {
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float32ToFloat64(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		encoded, _ := json.Marshal(v)
+		return string(encoded[1 : len(encoded)-1])
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i := 0; i < len(v); i++ {
+			values[i] = toJSONValue(v[i])
+		}
+		return values
+	case DocumentRaw:
+		return toJSONDocument(v)
+	case *NamedList:
+		return namedListToJSON(v)
+	case *DocumentList:
+		documents := make([]interface{}, len(v.Docs))
+		for i := 0; i < len(v.Docs); i++ {
+			documents[i] = toJSONDocument(v.Docs[i])
+		}
+		list := map[string]interface{}{rawNumFound: float64(v.NumFound), rawStart: float64(v.Start), rawDocs: documents}
+		if v.MaxScore != nil {
+			list[rawMaxScore] = float32ToFloat64(*v.MaxScore)
+		}
+		return list
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			values[k] = toJSONValue(item)
+		}
+		return values
+	default:
+		return value
+	}
+
+}
+
+// toJSONDocument - converts the document values to the types decoded from the JSON format, the child
+// documents are converted to map[string]interface{} as encoding/json does
+func toJSONDocument(document DocumentRaw) map[string]interface{} {
+
+	values := make(map[string]interface{}, len(document))
+	for k, item := range document {
+		values[k] = toJSONValue(item)
+	}
+
+	return values
+
+}
+
+// namedListToJSON - an object when ordered, a flat array of names and values otherwise
+func namedListToJSON(list *NamedList) interface{} {
+
+	if list.Ordered {
+
+		values := make(map[string]interface{}, len(list.Names))
+		for i := len(list.Names) - 1; i >= 0; i-- {
+			values[list.Names[i]] = toJSONValue(list.Values[i])
+		}
+
+		return values
+	}
+
+	values := make([]interface{}, 0, len(list.Names)*2)
+	for i := 0; i < len(list.Names); i++ {
+
+		var name interface{} = list.Names[i]
+		if list.nulls[i] {
+			name = nil
+		}
+
+		values = append(values, name, toJSONValue(list.Values[i]))
+	}
+
+	return values
+
+}
@@ -0,0 +1,284 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/buger/jsonparser"
+)
+
+// decodeJavabin - decodes a javabin search response into the same Response of the JSON format, the sections are read
+// from the decoded named lists by the same parsers of the JSON format. The DefaultDocumentParser documents are converted
+// directly, the other DocumentParsers parse JSON so they receive the response section written as JSON,
+// ex: {"response":{"numFound":1,"start":0,"docs":[...]}}, and the documents are decoded and encoded once more
+func (s *Instance) decodeJavabin(raw []byte, facet bool, parser DocumentParser) (*Response, error) {
+
+	value, err := UnmarshalJavabin(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing javabin: %v", err.Error())
+	}
+
+	root, ok := value.(*NamedList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing javabin: unexpected response type %T", value)
+	}
+
+	return s.decodeResponse(javabinNode{value: root}, facet, func() (interface{}, error) {
+		return javabinDocuments(root, parser)
+	})
+
+}
+
+// javabinDocuments - converts the documents directly for the DefaultDocumentParser, the other parsers parse them as JSON
+func javabinDocuments(root *NamedList, parser DocumentParser) (interface{}, error) {
+
+	response, _ := root.Get(rawResponse)
+
+	list, ok := response.(*DocumentList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s type %T", rawResponse, response)
+	}
+
+	if _, isDefault := parser.(*DefaultDocumentParser); isDefault {
+
+		documents := make([]DocumentRaw, len(list.Docs))
+		for i := 0; i < len(list.Docs); i++ {
+			documents[i] = toJSONDocument(list.Docs[i])
+		}
+
+		return documents, nil
+	}
+
+	raw, err := json.Marshal(map[string]*DocumentList{rawResponse: list})
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.Parse(raw)
+
+}
+
+// javabinNode - a value decoded from a javabin response, the named lists, maps and document lists are objects,
+// the null names of a named list have the null key type
+type javabinNode struct {
+	value interface{}
+}
+
+func (n javabinNode) Get(keys ...string) (responseNode, bool) {
+
+	value := n.value
+
+	for _, key := range keys {
+
+		var ok bool
+
+		switch v := value.(type) {
+		case *NamedList:
+			value, ok = v.Get(key)
+		case map[string]interface{}:
+			value, ok = v[key]
+		case DocumentRaw:
+			value, ok = v[key]
+		case *DocumentList:
+			value, ok = documentListGet(v, key)
+		}
+
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return javabinNode{value: value}, true
+
+}
+
+// documentListGet - the numFound, start and maxScore of a document list
+func documentListGet(list *DocumentList, key string) (interface{}, bool) {
+
+	switch key {
+	case rawNumFound:
+		return list.NumFound, true
+	case rawStart:
+		return list.Start, true
+	case rawMaxScore:
+
+		if list.MaxScore == nil {
+			return nil, false
+		}
+
+		return *list.MaxScore, true
+
+	default:
+		return nil, false
+	}
+
+}
+
+// Each - iterates over a named list, or a map sorted by key
+func (n javabinNode) Each(callback func(key string, keyType jsonparser.ValueType, value responseNode) error) error {
+
+	switch v := n.value.(type) {
+	case *NamedList:
+
+		for i := 0; i < len(v.Names); i++ {
+
+			keyType := jsonparser.String
+			if v.nulls[i] {
+				keyType = jsonparser.Null
+			}
+
+			if err := callback(v.Names[i], keyType, javabinNode{value: v.Values[i]}); err != nil {
+				return err
+			}
+		}
+
+	case map[string]interface{}:
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if err := callback(k, jsonparser.String, javabinNode{value: v[k]}); err != nil {
+				return err
+			}
+		}
+
+	case nil:
+
+	default:
+
+		return fmt.Errorf("unexpected named list type %T", n.value)
+	}
+
+	return nil
+
+}
+
+func (n javabinNode) Items(callback func(value responseNode) error) error {
+
+	switch v := n.value.(type) {
+	case []interface{}:
+
+		for i := 0; i < len(v); i++ {
+			if err := callback(javabinNode{value: v[i]}); err != nil {
+				return err
+			}
+		}
+
+	case nil:
+
+	default:
+
+		return fmt.Errorf("unexpected list type %T", n.value)
+	}
+
+	return nil
+
+}
+
+func (n javabinNode) Kind() jsonparser.ValueType {
+
+	switch n.value.(type) {
+	case int8, int16, int32, int64, float32, float64:
+		return jsonparser.Number
+	case string, time.Time, []byte:
+		return jsonparser.String
+	case bool:
+		return jsonparser.Boolean
+	case []interface{}:
+		return jsonparser.Array
+	case *NamedList, map[string]interface{}, DocumentRaw, *DocumentList:
+		return jsonparser.Object
+	case nil:
+		return jsonparser.Null
+	default:
+		return jsonparser.Unknown
+	}
+
+}
+
+func (n javabinNode) Int() (int64, error) {
+
+	switch v := n.value.(type) {
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unexpected integer type %T", n.value)
+	}
+
+}
+
+// Float - a number as float64, the float32 values are converted as the JSON format writes them
+func (n javabinNode) Float() (float64, error) {
+
+	switch v := n.value.(type) {
+	case float32:
+		return float32ToFloat64(v), nil
+	case float64:
+		return v, nil
+	case nil:
+		return 0, nil
+	default:
+
+		number, err := n.Int()
+		if err != nil {
+			return 0, fmt.Errorf("unexpected number type %T", n.value)
+		}
+
+		return float64(number), nil
+	}
+
+}
+
+func (n javabinNode) Bool() (bool, error) {
+
+	b, ok := n.value.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected boolean type %T", n.value)
+	}
+
+	return b, nil
+
+}
+
+// Text - a string, the dates and byte arrays are written as the JSON format writes them
+func (n javabinNode) Text() (string, error) {
+
+	switch v := n.value.(type) {
+	case string:
+		return v, nil
+	case time.Time, []byte:
+		return toJSONValue(v).(string), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected string type %T", n.value)
+	}
+
+}
+
+func (n javabinNode) JSON() ([]byte, error) {
+
+	return json.Marshal(toJSONValue(n.value))
+}
+
+// float32ToFloat64 - converts with the shortest decimal representation, the value the JSON format returns
+func float32ToFloat64(value float32) float64 {
+
+	converted, _ := strconv.ParseFloat(strconv.FormatFloat(float64(value), 'g', -1, 32), 64)
+
+	return converted
+
+}
@@ -0,0 +1,413 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalJavabin(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		raw      []byte
+		expected interface{}
+	}{
+		{
+			name:     "ordered named list",
+			raw:      []byte{2, 0xa2, 0x21, 'a', 0x41, 0x21, 'b', 0x26, 'h', 'e', 'l', 'l', 'o', '!'},
+			expected: &NamedList{Names: []string{"a", "b"}, Values: []interface{}{int32(1), "hello!"}, Ordered: true},
+		},
+		{
+			name:     "named list with a null name",
+			raw:      []byte{2, 0xc2, 0x21, 'x', 0x42, 0x00, 0x43},
+			expected: &NamedList{Names: []string{"x", ""}, Values: []interface{}{int32(2), int32(3)}, nulls: map[int]bool{1: true}},
+		},
+		{
+			name:     "map",
+			raw:      []byte{2, 10, 1, 0x21, 'k', 0x41},
+			expected: map[string]interface{}{"k": int32(1)},
+		},
+		{
+			name:     "iterator",
+			raw:      []byte{2, 14, 0x41, 0x21, 'a', 1, 15},
+			expected: []interface{}{int32(1), "a", true},
+		},
+		{
+			name:     "extern strings",
+			raw:      []byte{2, 0x83, 0xe0, 0x22, 'i', 'd', 0xe1, 0xe0, 0x21, 'x'},
+			expected: []interface{}{"id", "id", "x"},
+		},
+		{
+			name:     "date",
+			raw:      []byte{2, 9, 0x00, 0x00, 0x01, 0x6f, 0x64, 0x35, 0xcc, 0x8e},
+			expected: time.Date(2020, 1, 2, 3, 4, 5, 6*int(time.Millisecond), time.UTC),
+		},
+		{
+			name: "document list with a child document",
+			raw: []byte{2, 12, 0x83, 0x62, 0x60, 8, 0x3f, 0xc0, 0x00, 0x00, 0x81,
+				11, 0xa2, 0x22, 'i', 'd', 0x21, '1', 11, 0xa1, 0x22, 'i', 'd', 0x23, '1', '-', '1'},
+			expected: &DocumentList{NumFound: 2, MaxScore: float32Pointer(1.5), Docs: []DocumentRaw{
+				{"id": "1", "_childDocuments_": []interface{}{DocumentRaw{"id": "1-1"}}},
+			}},
+		},
+		{
+			name:     "document list without max score",
+			raw:      []byte{2, 12, 0x83, 0x60, 0x60, 0, 0x80},
+			expected: &DocumentList{Docs: []DocumentRaw{}},
+		},
+		{
+			name:     "small int",
+			raw:      []byte{2, 0x4c},
+			expected: int32(12),
+		},
+		{
+			name:     "small int with a vint",
+			raw:      []byte{2, 0x5c, 0x12},
+			expected: int32(300),
+		},
+		{
+			name:     "small int with a two bytes vint",
+			raw:      []byte{2, 0x50, 0xea, 0x30},
+			expected: int32(100000),
+		},
+		{
+			name:     "small long with a vlong",
+			raw:      []byte{2, 0x70, 0x80, 0x80, 0x80, 0x80, 0x80, 0x02},
+			expected: int64(1 << 40),
+		},
+		{
+			name:     "string size with a vint",
+			raw:      append([]byte{2, 0x3f, 9}, bytes.Repeat([]byte{'a'}, 40)...),
+			expected: string(bytes.Repeat([]byte{'a'}, 40)),
+		},
+		{
+			name: "fixed size numbers",
+			raw: []byte{2, 0x86, 3, 0xff, 4, 0xff, 0xfe, 6, 0x00, 0x01, 0x00, 0x00,
+				7, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 8, 0x3f, 0xc0, 0x00, 0x00,
+				5, 0x40, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			expected: []interface{}{int8(-1), int16(-2), int32(65536), int64(-1), float32(1.5), float64(2.5)},
+		},
+	}
+
+	for _, c := range cases {
+
+		value, err := UnmarshalJavabin(c.raw)
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, c.expected, value, c.name)
+		}
+	}
+}
+
+func TestUnmarshalJavabinErrors(t *testing.T) {
+
+	cases := []struct {
+		name string
+		raw  []byte
+	}{
+		{"empty", []byte{}},
+		{"unsupported version", []byte{1, 0}},
+		{"truncated string", []byte{2, 0x25, 'a'}},
+		{"truncated long", []byte{2, 7, 0x00}},
+		{"undefined extern string", []byte{2, 0xe1}},
+		{"unterminated iterator", []byte{2, 14, 0x41}},
+		{"unknown tag", []byte{2, 30}},
+	}
+
+	for _, c := range cases {
+
+		_, err := UnmarshalJavabin(c.raw)
+		assert.Error(t, err, c.name)
+	}
+
+	_, err := UnmarshalJavabin(nil)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func float32Pointer(value float32) *float32 {
+
+	return &value
+}
+
+// javabinWriter - writes the values as solr does, used to build the responses of the tests
+type javabinWriter struct {
+	bytes.Buffer
+}
+
+func (w *javabinWriter) writeSize(tag byte, size int) {
+
+	if size < int(javabinTagSizeMask) {
+		w.WriteByte(tag | byte(size))
+		return
+	}
+
+	w.WriteByte(tag | javabinTagSizeMask)
+
+	for size -= int(javabinTagSizeMask); size > 0x7f; size >>= 7 {
+		w.WriteByte(byte(size&0x7f) | 0x80)
+	}
+	w.WriteByte(byte(size))
+}
+
+func (w *javabinWriter) writeFixed(tag byte, value uint64, size int) {
+
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, value)
+
+	w.WriteByte(tag)
+	w.Write(b[8-size:])
+}
+
+func (w *javabinWriter) writeValue(value interface{}) {
+
+	switch v := value.(type) {
+	case nil:
+		w.WriteByte(javabinNull)
+	case bool:
+		if v {
+			w.WriteByte(javabinTrue)
+		} else {
+			w.WriteByte(javabinFalse)
+		}
+	case int32:
+		w.writeFixed(javabinInt, uint64(uint32(v)), 4)
+	case int64:
+		w.writeFixed(javabinLong, uint64(v), 8)
+	case float32:
+		w.writeFixed(javabinFloat, uint64(math.Float32bits(v)), 4)
+	case float64:
+		w.writeFixed(javabinDouble, math.Float64bits(v), 8)
+	case time.Time:
+		w.writeFixed(javabinDate, uint64(v.UnixNano()/int64(time.Millisecond)), 8)
+	case string:
+		w.writeSize(javabinString, len(v))
+		w.WriteString(v)
+	case []interface{}:
+		w.writeSize(javabinArray, len(v))
+		for _, item := range v {
+			w.writeValue(item)
+		}
+	case *NamedList:
+		if v.Ordered {
+			w.writeSize(javabinOrderedMap, len(v.Names))
+		} else {
+			w.writeSize(javabinNamedList, len(v.Names))
+		}
+		for i := range v.Names {
+			if v.nulls[i] {
+				w.writeValue(nil)
+			} else {
+				w.writeValue(v.Names[i])
+			}
+			w.writeValue(v.Values[i])
+		}
+	case DocumentRaw:
+		size := len(v)
+		children, ok := v[rawChildDocuments].([]interface{})
+		if ok {
+			size += len(children) - 1
+		}
+		w.WriteByte(javabinSolrDoc)
+		w.writeSize(javabinOrderedMap, size)
+		for name, item := range v {
+			if name != rawChildDocuments {
+				w.writeValue(name)
+				w.writeValue(item)
+			}
+		}
+		for _, child := range children {
+			w.writeValue(child)
+		}
+	case *DocumentList:
+		var maxScore interface{}
+		if v.MaxScore != nil {
+			maxScore = *v.MaxScore
+		}
+		docs := make([]interface{}, len(v.Docs))
+		for i := range v.Docs {
+			docs[i] = v.Docs[i]
+		}
+		w.WriteByte(javabinSolrDocList)
+		w.writeValue([]interface{}{v.NumFound, v.Start, maxScore})
+		w.writeValue(docs)
+	}
+}
+
+// ordered - an ordered named list of the names and values
+func ordered(entries ...interface{}) *NamedList {
+
+	list := &NamedList{Ordered: true}
+	for i := 0; i < len(entries); i += 2 {
+		list.Names = append(list.Names, entries[i].(string))
+		list.Values = append(list.Values, entries[i+1])
+	}
+
+	return list
+}
+
+// javabinResponse - the javabin equivalent of javabinResponseJSON
+func javabinResponse() []byte {
+
+	date := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	suggestions := ordered("solr", ordered(
+		"numFound", int32(1), "startOffset", int32(0), "endOffset", int32(4), "origFreq", int32(0),
+		"suggestion", []interface{}{ordered("word", "solar", "freq", int32(3))},
+	))
+	suggestions.Ordered = false
+
+	corrections := ordered("solr", "solar")
+	corrections.Ordered = false
+
+	collations := ordered("collation", ordered("collationQuery", "solar", "hits", int64(3), "misspellingsAndCorrections", corrections))
+	collations.Ordered = false
+
+	percentiles := ordered("50.0", 1.5, "99.0", 2.0)
+	percentiles.Ordered = false
+
+	tagValues := &NamedList{Names: []string{"a", "b", ""}, Values: []interface{}{int32(2), int32(0), int32(1)}, nulls: map[int]bool{2: true}}
+
+	root := ordered(
+		"responseHeader", ordered("zkConnected", true, "status", int32(0), "QTime", int32(3),
+			"params", ordered("q", "*:*", "fq", []interface{}{"a", "b"})),
+		"response", &DocumentList{NumFound: 2, Start: 1, MaxScore: float32Pointer(1.1), Docs: []DocumentRaw{
+			{"id": "1", "count": int32(5), "price": float32(1.1), "date": date, "tags": []interface{}{"a", "b"},
+				"_childDocuments_": []interface{}{DocumentRaw{"id": "1-1"}}},
+		}},
+		"nextCursorMark", "AoE",
+		"facet_counts", ordered("facet_queries", ordered(), "facet_fields", ordered("tag", tagValues)),
+		"facets", ordered("count", int64(2), "tags", ordered("buckets", []interface{}{ordered("val", "a", "count", int64(2))})),
+		"spellcheck", ordered("suggestions", suggestions, "correctlySpelled", false, "collations", collations),
+		"stats", ordered("stats_fields", ordered(
+			"price", ordered("min", 1.0, "max", 2.0, "count", int64(2), "missing", int64(0), "sum", 3.0,
+				"sumOfSquares", 5.0, "mean", 1.5, "stddev", 0.5, "percentiles", percentiles,
+				"facets", ordered("tag", ordered("a", ordered("min", 1.0, "max", 1.0, "count", int64(1))))),
+			"date", ordered("min", date, "max", date, "count", int64(2), "mean", date),
+			"empty", nil,
+		)),
+		"debug", ordered(
+			"rawquerystring", "*:*", "querystring", "*:*", "parsedquery", "MatchAllDocsQuery(*:*)",
+			"parsedquery_toString", "*:*", "QParser", "LuceneQParser",
+			"filter_queries", []interface{}{"a"}, "parsed_filter_queries", []interface{}{"a"},
+			"timing", ordered("time", 1.0, "prepare", ordered("time", 0.0, "query", ordered("time", 0.0)),
+				"process", ordered("time", 1.0, "query", ordered("time", 1.0))),
+			"explain", ordered("1", ordered("match", true, "value", float32(1.1), "description", "sum of:",
+				"details", []interface{}{ordered("match", true, "value", float32(1), "description", "x")})),
+		),
+	)
+
+	w := &javabinWriter{}
+	w.WriteByte(javabinVersion)
+	w.writeValue(root)
+
+	return w.Bytes()
+}
+
+// javabinResponseJSON - the response of javabinResponse as written by the JSON response writer
+const javabinResponseJSON = `{
+"responseHeader":{"zkConnected":true,"status":0,"QTime":3,"params":{"q":"*:*","fq":["a","b"]}},
+"response":{"numFound":2,"start":1,"maxScore":1.1,"docs":[
+	{"id":"1","count":5,"price":1.1,"date":"2020-01-02T03:04:05Z","tags":["a","b"],"_childDocuments_":[{"id":"1-1"}]}]},
+"nextCursorMark":"AoE",
+"facet_counts":{"facet_queries":{},"facet_fields":{"tag":["a",2,"b",0,null,1]}},
+"facets":{"count":2,"tags":{"buckets":[{"val":"a","count":2}]}},
+"spellcheck":{
+	"suggestions":["solr",{"numFound":1,"startOffset":0,"endOffset":4,"origFreq":0,"suggestion":[{"word":"solar","freq":3}]}],
+	"correctlySpelled":false,
+	"collations":["collation",{"collationQuery":"solar","hits":3,"misspellingsAndCorrections":["solr","solar"]}]},
+"stats":{"stats_fields":{
+	"price":{"min":1.0,"max":2.0,"count":2,"missing":0,"sum":3.0,"sumOfSquares":5.0,"mean":1.5,"stddev":0.5,
+		"percentiles":["50.0",1.5,"99.0",2.0],"facets":{"tag":{"a":{"min":1.0,"max":1.0,"count":1}}}},
+	"date":{"min":"2020-01-02T03:04:05Z","max":"2020-01-02T03:04:05Z","count":2,"mean":"2020-01-02T03:04:05Z"},
+	"empty":null}},
+"debug":{"rawquerystring":"*:*","querystring":"*:*","parsedquery":"MatchAllDocsQuery(*:*)",
+	"parsedquery_toString":"*:*","QParser":"LuceneQParser","filter_queries":["a"],"parsed_filter_queries":["a"],
+	"timing":{"time":1.0,"prepare":{"time":0.0,"query":{"time":0.0}},"process":{"time":1.0,"query":{"time":1.0}}},
+	"explain":{"1":{"match":true,"value":1.1,"description":"sum of:","details":[{"match":true,"value":1.0,"description":"x"}]}}}
+}`
+
+func TestDecodeJavabin(t *testing.T) {
+
+	s := &Instance{}
+
+	expected, err := s.decode([]byte(javabinResponseJSON), true, &DefaultDocumentParser{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// every section of the JSON response is decoded
+	assert.Len(t, expected.Facets, 1)
+	assert.Len(t, expected.Stats, 3)
+	assert.Len(t, expected.Spellcheck.Collations, 1)
+	assert.Len(t, expected.Debug.Explain, 1)
+	assert.NotEmpty(t, expected.JSONFacets)
+
+	res, err := s.decodeJavabin(javabinResponse(), true, &DefaultDocumentParser{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, expected.Status, res.Status)
+	assert.Equal(t, expected.QTime, res.QTime)
+	assert.Equal(t, expected.NumFound, res.NumFound)
+	assert.Equal(t, expected.Header, res.Header)
+	assert.Equal(t, expected.Docs, res.Docs)
+	assert.Equal(t, expected.Facets, res.Facets)
+	assert.Equal(t, expected.JSONFacets, res.JSONFacets)
+	assert.Equal(t, expected.Spellcheck, res.Spellcheck)
+	assert.Equal(t, expected.Stats, res.Stats)
+	assert.Equal(t, expected.Debug, res.Debug)
+}
+
+func TestDecodeJavabinOtherDocumentParser(t *testing.T) {
+
+	s := &Instance{}
+
+	fields := map[string]string{}
+	parser := &VisitorDocumentParser{Visitor: func(document int, name []byte, dataType jsonparser.ValueType, value []byte) error {
+		fields[string(name)] = string(value)
+		return nil
+	}}
+
+	res, err := s.decodeJavabin(javabinResponse(), false, parser)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), res.NumFound)
+		assert.Equal(t, 1, res.Docs)
+		assert.Equal(t, "1", fields["id"])
+		assert.Equal(t, "5", fields["count"])
+		assert.Equal(t, "2020-01-02T03:04:05Z", fields["date"])
+	}
+}
+
+func TestDecodeJavabinErrors(t *testing.T) {
+
+	s := &Instance{}
+
+	w := &javabinWriter{}
+	w.WriteByte(javabinVersion)
+	w.writeValue(ordered("responseHeader", ordered("status", int32(0), "QTime", int32(1))))
+
+	_, err := s.decodeJavabin(w.Bytes(), false, &DefaultDocumentParser{})
+	assert.EqualError(t, err, "error parsing numbers: response.numFound not found")
+
+	w = &javabinWriter{}
+	w.WriteByte(javabinVersion)
+	w.writeValue(ordered(
+		"responseHeader", ordered("status", int32(0), "QTime", int32(1)),
+		"response", &DocumentList{Docs: []DocumentRaw{}},
+		"facet_counts", ordered("facet_fields", ordered("tag", ordered("a", "x"))),
+	))
+
+	_, err = s.decodeJavabin(w.Bytes(), true, &DefaultDocumentParser{})
+
+	var facetError *FacetError
+	if assert.ErrorAs(t, err, &facetError) {
+		assert.Equal(t, "tag", facetError.Field)
+	}
+}
@@ -0,0 +1,353 @@
+package solr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// MoreLikeThisParams - Params for MoreLikeThis queries, for more information visit https://lucene.apache.org/solr/guide/7_4/morelikethis.html
+type MoreLikeThisParams struct {
+	ID               string   //ID - the id of the document used as source, use it or Stream
+	Stream           string   //Stream - a text used as source, only supported by the /mlt handler
+	UseHandler       bool     //UseHandler - uses the /mlt handler instead of the mlt component on /select
+	Fields           []string //Fields - the fields used for similarity (mlt.fl)
+	MinDF            int      //MinDF - minimum document frequency of a term (mlt.mindf)
+	MinTF            int      //MinTF - minimum term frequency in the source document (mlt.mintf)
+	MaxQT            int      //MaxQT - maximum number of query terms (mlt.maxqt)
+	Boost            bool     //Boost - boosts the query terms by their relevance (mlt.boost)
+	InterestingTerms string   //InterestingTerms - list, details or none (mlt.interestingTerms)
+	FL               string
+	FilterQueries    []string
+	Rows             int
+	Start            int
+}
+
+//MoreLikeThisResponse - response data from a MoreLikeThis query
+type MoreLikeThisResponse struct {
+	Status           int64
+	QTime            int64
+	Match            *MoreLikeThisResult //Match - the source documents
+	Similar          []MoreLikeThisResult
+	InterestingTerms []InterestingTerm
+}
+
+//MoreLikeThisResult - the documents similar to the document with the ID, the ID is empty for stream queries
+type MoreLikeThisResult struct {
+	ID       string
+	NumFound int64
+	Start    int64
+	Docs     interface{}
+}
+
+//InterestingTerm - a term used by the MoreLikeThis query, the boost is only filled with details
+type InterestingTerm struct {
+	Term  string
+	Boost float64
+}
+
+func (params *MoreLikeThisParams) toQueryString() string {
+
+	qs := strings.Builder{}
+
+	if !params.UseHandler {
+		qs.WriteString(stringMLTTrue)
+	}
+
+	if params.ID != "" {
+		writeParam(&qs, stringQ, stringIDQuery+EscapeQueryChars(params.ID))
+	}
+
+	if len(params.Fields) > 0 {
+		writeParam(&qs, stringMLTFL, strings.Join(params.Fields, stringComma))
+	}
+
+	if params.MinDF > 0 {
+		writeParam(&qs, stringMLTMinDF, strconv.Itoa(params.MinDF))
+	}
+
+	if params.MinTF > 0 {
+		writeParam(&qs, stringMLTMinTF, strconv.Itoa(params.MinTF))
+	}
+
+	if params.MaxQT > 0 {
+		writeParam(&qs, stringMLTMaxQT, strconv.Itoa(params.MaxQT))
+	}
+
+	if params.Boost {
+		qs.WriteString(stringMLTBoostTrue)
+	}
+
+	if params.InterestingTerms != "" {
+		writeParam(&qs, stringMLTInterestingTerms, params.InterestingTerms)
+	}
+
+	for i := 0; i < len(params.FilterQueries); i++ {
+		writeParam(&qs, stringFQ, params.FilterQueries[i])
+	}
+
+	if params.FL != "" {
+		writeParam(&qs, stringFL, params.FL)
+	}
+
+	if params.UseHandler {
+
+		if params.ID != "" {
+			qs.WriteString(stringMLTMatchIncludeTrue)
+		}
+
+		qs.WriteString(stringStart)
+		qs.WriteString(strconv.Itoa(params.Start))
+
+		qs.WriteString(stringRows)
+		qs.WriteString(strconv.Itoa(params.Rows))
+
+	} else {
+
+		writeParam(&qs, stringMLTCount, strconv.Itoa(params.Rows))
+
+	}
+
+	return qs.String()
+
+}
+
+// MoreLikeThis - finds the documents similar to a document id or to a stream body
+func (s *Instance) MoreLikeThis(params *MoreLikeThisParams, instanceName string) (*MoreLikeThisResponse, error) {
+
+	if params == nil {
+		return nil, fmt.Errorf("params cannot be null")
+	}
+
+	if (params.ID == "") == (params.Stream == "") {
+		return nil, fmt.Errorf("one of ID or Stream must be defined")
+	}
+
+	if params.Stream != "" && !params.UseHandler {
+		return nil, fmt.Errorf("stream queries are only supported by the MoreLikeThis handler")
+	}
+
+	var searchType string
+	if params.UseHandler {
+		searchType = stringMLT
+	} else {
+		searchType = stringSelect
+	}
+
+	stringParams := params.toQueryString()
+
+	url := strings.Builder{}
+
+	url.Grow(len(s.coreURL) + len(stringBar)*3 + len(searchType) + len(stringSolrBase) + len(instanceName) + len(stringParams))
+
+	url.WriteString(s.coreURL)
+	url.WriteString(stringBar)
+	url.WriteString(stringSolrBase)
+	url.WriteString(stringBar)
+	url.WriteString(instanceName)
+	url.WriteString(stringBar)
+	url.WriteString(searchType)
+	url.WriteString(stringParams)
+
+	var raw []byte
+	var err error
+
+	if params.Stream != "" {
+
+		var resp string
+		resp, err = s.httpPost(url.String(), contentTypeText, params.Stream)
+		raw = []byte(resp)
+
+	} else {
+
+		raw, err = s.httpGet(url.String())
+
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decodeMoreLikeThis(raw, params.UseHandler, params.ID)
+}
+
+func (s *Instance) decodeMoreLikeThis(raw []byte, handler bool, id string) (*MoreLikeThisResponse, error) {
+
+	res := &MoreLikeThisResponse{}
+	var err error
+
+	if res.Status, err = jsonparser.GetInt(raw, rawResponseHeader, rawStatus); err != nil {
+		return nil, fmt.Errorf("error parsing status: %v", err.Error())
+	}
+
+	if res.Status != 0 {
+		return nil, fmt.Errorf("Solr returned status %d", res.Status)
+	}
+
+	if res.QTime, err = jsonparser.GetInt(raw, rawResponseHeader, rawQtime); err != nil {
+		return nil, fmt.Errorf("error parsing QTime: %v", err.Error())
+	}
+
+	if handler {
+
+		if value, _, _, err := jsonparser.Get(raw, rawMatch); err == nil {
+
+			match, err := s.parseMoreLikeThisResult(id, value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing match: %v", err.Error())
+			}
+
+			res.Match = &match
+		}
+
+		value, _, _, err := jsonparser.Get(raw, rawResponse)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %v", err.Error())
+		}
+
+		similar, err := s.parseMoreLikeThisResult(id, value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing docs: %v", err.Error())
+		}
+
+		res.Similar = append(res.Similar, similar)
+
+	} else {
+
+		value, _, _, err := jsonparser.Get(raw, rawResponse)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %v", err.Error())
+		}
+
+		match, err := s.parseMoreLikeThisResult(id, value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing match: %v", err.Error())
+		}
+
+		res.Match = &match
+
+		err = eachNamedList(raw, func(key, value []byte, dataType jsonparser.ValueType) error {
+
+			similar, err := s.parseMoreLikeThisResult(string(key), value)
+			if err != nil {
+				return err
+			}
+
+			res.Similar = append(res.Similar, similar)
+			return nil
+		}, rawMoreLikeThis)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing docs: %v", err.Error())
+		}
+
+	}
+
+	if res.InterestingTerms, err = parseInterestingTerms(raw); err != nil {
+		return nil, fmt.Errorf("error parsing interesting terms: %v", err.Error())
+	}
+
+	return res, nil
+
+}
+
+func (s *Instance) parseMoreLikeThisResult(id string, raw []byte) (MoreLikeThisResult, error) {
+
+	var err error
+
+	result := MoreLikeThisResult{ID: id}
+
+	if result.NumFound, err = jsonparser.GetInt(raw, rawNumFound); err != nil {
+		return result, err
+	}
+
+	if result.Start, err = jsonparser.GetInt(raw, rawStart); err != nil {
+		return result, err
+	}
+
+	result.Docs, err = s.parseDocumentList(raw)
+
+	return result, err
+
+}
+
+// parseDocumentList - parses a document list outside of the main response using the configured DocumentParser
+func (s *Instance) parseDocumentList(raw []byte) (interface{}, error) {
+
+	documents := make([]byte, 0, len(rawDocumentListPrefix)+len(raw)+len(rawDocumentListSuffix))
+	documents = append(documents, rawDocumentListPrefix...)
+	documents = append(documents, raw...)
+	documents = append(documents, rawDocumentListSuffix...)
+
+	return s.documentParser.Parse(documents)
+
+}
+
+func parseInterestingTerms(raw []byte) ([]InterestingTerm, error) {
+
+	value, dataType, _, err := jsonparser.Get(raw, rawInterestingTerms)
+	if err == jsonparser.KeyPathNotFoundError {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []InterestingTerm
+
+	if dataType == jsonparser.Array {
+
+		var details bool
+		var parseError error
+
+		_, err = jsonparser.ArrayEach(value, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+			if dataType == jsonparser.Number {
+				details = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !details {
+
+			_, err = jsonparser.ArrayEach(value, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+				if parseError != nil {
+					return
+				}
+
+				term := InterestingTerm{}
+				term.Term, parseError = jsonparser.ParseString(value)
+				terms = append(terms, term)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return terms, parseError
+		}
+	}
+
+	err = eachNamedList(raw, func(key, value []byte, dataType jsonparser.ValueType) error {
+
+		var err error
+
+		term := InterestingTerm{}
+
+		if term.Term, err = jsonparser.ParseString(key); err != nil {
+			return err
+		}
+
+		if term.Boost, err = jsonparser.ParseFloat(value); err != nil {
+			return err
+		}
+
+		terms = append(terms, term)
+		return nil
+	}, rawInterestingTerms)
+
+	return terms, err
+
+}
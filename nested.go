@@ -0,0 +1,250 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// NestedDocument - a parent document with its child documents, for more information visit https://lucene.apache.org/solr/guide/7_4/uploading-data-with-index-handlers.html#nested-child-documents
+type NestedDocument struct {
+	Fields   DocumentRaw                  //Fields - the document fields, without the children
+	Children []*NestedDocument            //Children - the anonymous children, sent as _childDocuments_
+	Labeled  map[string][]*NestedDocument //Labeled - the children by field name, supported since Solr 8
+}
+
+// NewNestedDocument - creates a nested document with the fields
+func NewNestedDocument(fields DocumentRaw) *NestedDocument {
+
+	if fields == nil {
+		fields = DocumentRaw{}
+	}
+
+	return &NestedDocument{Fields: fields}
+
+}
+
+// AddChildren - adds anonymous children
+func (d *NestedDocument) AddChildren(children ...*NestedDocument) *NestedDocument {
+
+	d.Children = append(d.Children, children...)
+
+	return d
+
+}
+
+// AddLabeledChildren - adds children to the labeled field
+func (d *NestedDocument) AddLabeledChildren(label string, children ...*NestedDocument) *NestedDocument {
+
+	if d.Labeled == nil {
+		d.Labeled = map[string][]*NestedDocument{}
+	}
+
+	d.Labeled[label] = append(d.Labeled[label], children...)
+
+	return d
+
+}
+
+// Descendants - all the children of the document and of its children
+func (d *NestedDocument) Descendants() []*NestedDocument {
+
+	var descendants []*NestedDocument
+
+	for _, child := range d.Children {
+		descendants = append(descendants, child)
+		descendants = append(descendants, child.Descendants()...)
+	}
+
+	for _, children := range d.Labeled {
+		for _, child := range children {
+			descendants = append(descendants, child)
+			descendants = append(descendants, child.Descendants()...)
+		}
+	}
+
+	return descendants
+
+}
+
+// MarshalJSON - writes the fields with the anonymous children in _childDocuments_ and the labeled children in their fields
+func (d *NestedDocument) MarshalJSON() ([]byte, error) {
+
+	return json.Marshal(d.toMap(false))
+
+}
+
+// toMap - the document as a map, flatten writes the labeled children as anonymous children
+func (d *NestedDocument) toMap(flatten bool) map[string]interface{} {
+
+	document := make(map[string]interface{}, len(d.Fields)+len(d.Labeled)+1)
+	for k, v := range d.Fields {
+		document[k] = v
+	}
+
+	children := make([]interface{}, 0, len(d.Children))
+	for _, child := range d.Children {
+		children = append(children, child.toMap(flatten))
+	}
+
+	for label, labeled := range d.Labeled {
+
+		if flatten {
+			for _, child := range labeled {
+				children = append(children, child.toMap(flatten))
+			}
+			continue
+		}
+
+		values := make([]interface{}, len(labeled))
+		for i, child := range labeled {
+			values[i] = child.toMap(flatten)
+		}
+
+		document[label] = values
+	}
+
+	if len(children) > 0 {
+		document[rawChildDocuments] = children
+	}
+
+	return document
+
+}
+
+// NestedDocumentWriter - writes NestedDocument payloads, any other payload is written as JSON
+type NestedDocumentWriter struct {
+	Flatten bool //Flatten - writes the labeled children as anonymous _childDocuments_, for Solr versions before 8
+}
+
+// Writer - writes the nested documents as JSON
+func (w *NestedDocumentWriter) Writer(payload interface{}) ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+
+	if err := w.Stream(&buffer, payload); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+
+}
+
+// Stream - writes the nested documents as JSON to the output, the documents of a slice are converted one at a time
+func (w *NestedDocumentWriter) Stream(output io.Writer, payload interface{}) error {
+
+	switch p := payload.(type) {
+	case *NestedDocument:
+		payload = p.toMap(w.Flatten)
+	case NestedDocument:
+		payload = p.toMap(w.Flatten)
+	}
+
+	return streamJSONArray(output, payload, func(index int, elem reflect.Value) (interface{}, error) {
+		return w.toPayload(elem.Interface()), nil
+	})
+
+}
+
+// toPayload - converts a NestedDocument to a map, any other value is written as it is
+func (w *NestedDocumentWriter) toPayload(document interface{}) interface{} {
+
+	switch d := document.(type) {
+	case *NestedDocument:
+		return d.toMap(w.Flatten)
+	case NestedDocument:
+		return d.toMap(w.Flatten)
+	default:
+		return document
+	}
+
+}
+
+// NestedDocumentParser - parses the documents and their children returned by the [child] transformer as []*NestedDocument,
+// the _childDocuments_ become anonymous children and the fields with documents become labeled children
+type NestedDocumentParser struct {
+}
+
+// Parse - parses the documents of the response
+func (p *NestedDocumentParser) Parse(raw []byte) (interface{}, error) {
+
+	var resp responseRaw
+
+	err := json.Unmarshal(raw, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]*NestedDocument, len(resp.Data.Documents))
+	for i := 0; i < len(resp.Data.Documents); i++ {
+		documents[i] = toNestedDocument(resp.Data.Documents[i])
+	}
+
+	return documents, nil
+
+}
+
+func toNestedDocument(fields map[string]interface{}) *NestedDocument {
+
+	document := NewNestedDocument(nil)
+
+	for field, value := range fields {
+
+		if field == rawChildDocuments {
+
+			if children, ok := toDocumentList(value); ok {
+				for _, child := range children {
+					document.AddChildren(toNestedDocument(child))
+				}
+				continue
+			}
+		}
+
+		if children, ok := toDocumentList(value); ok {
+			for _, child := range children {
+				document.AddLabeledChildren(field, toNestedDocument(child))
+			}
+			continue
+		}
+
+		document.Fields[field] = value
+	}
+
+	return document
+
+}
+
+// toDocumentList - returns the documents of a field holding a document or a list of documents
+func toDocumentList(value interface{}) ([]map[string]interface{}, bool) {
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+
+		return []map[string]interface{}{v}, true
+
+	case []interface{}:
+
+		if len(v) == 0 {
+			return nil, false
+		}
+
+		documents := make([]map[string]interface{}, len(v))
+		for i := 0; i < len(v); i++ {
+
+			document, ok := v[i].(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			documents[i] = document
+		}
+
+		return documents, true
+
+	default:
+
+		return nil, false
+	}
+
+}
@@ -0,0 +1,155 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// SearchMethod - the HTTP method used by Search
+type SearchMethod int
+
+const (
+	// SearchAuto - GET, switching to a form POST when the URL is longer than the max URL length, the default
+	SearchAuto SearchMethod = iota
+	// SearchGet - always GET, long URLs may be rejected by solr or a proxy
+	SearchGet
+	// SearchPost - always POST the params as application/x-www-form-urlencoded
+	SearchPost
+)
+
+// DefaultMaxURLLength - the URL length that makes SearchAuto use POST, jetty rejects request lines longer than 8KB by default
+const DefaultMaxURLLength int = 4096
+
+// SetSearchMethod - sets the HTTP method of the searches, a maxURLLength of zero uses the DefaultMaxURLLength
+func (s *Instance) SetSearchMethod(method SearchMethod, maxURLLength int) {
+
+	if maxURLLength <= 0 {
+		maxURLLength = DefaultMaxURLLength
+	}
+
+	s.searchMethod = method
+	s.maxURLLength = maxURLLength
+
+}
+
+// usePost - true when the search must be sent as a POST
+func (s *Instance) usePost(urlLength int) bool {
+
+	switch s.searchMethod {
+	case SearchGet:
+		return false
+	case SearchPost:
+		return true
+	}
+
+	maxURLLength := s.maxURLLength
+	if maxURLLength <= 0 {
+		maxURLLength = DefaultMaxURLLength
+	}
+
+	return urlLength > maxURLLength
+
+}
+
+// JSONRequest - a search using the JSON Request API, for more information visit https://lucene.apache.org/solr/guide/7_4/json-request-api.html
+type JSONRequest struct {
+	Query   string                 `json:"query"`
+	Filter  []string               `json:"filter,omitempty"`
+	Fields  []string               `json:"fields,omitempty"`
+	Sort    string                 `json:"sort,omitempty"`
+	Offset  int                    `json:"offset,omitempty"` //Offset - the first document, zero is not written
+	Limit   int                    `json:"limit,omitempty"`  //Limit - the number of documents, zero is not written and solr returns its default of 10
+	Facet   map[string]interface{} `json:"facet,omitempty"`  //Facet - the JSON Facet API facets, returned in the Response.JSONFacets
+	Params  map[string]interface{} `json:"params,omitempty"` //Params - any other request params, ex: "facet.field", "df"
+	Handler string                 `json:"-"`                //Handler - the request handler path, ex: /query, default /select
+}
+
+// SearchJSON - searches sending the request as a JSON body to the Handler, the request is always JSON while the
+// response is decoded in the format of the Instance (wt=javabin is added to the Params for FormatJavabin), the facets of
+// the Facet field are returned in the Response.JSONFacets and the facet_fields, when requested with "facet" in the
+// Params, in the Response.Facets
+func (s *Instance) SearchJSON(request *JSONRequest, instanceName string) (*Response, error) {
+
+	if request == nil {
+		return nil, fmt.Errorf("request cannot be null")
+	}
+
+	if s.responseFormat == FormatJavabin {
+
+		params := make(map[string]interface{}, len(request.Params)+1)
+		for k, v := range request.Params {
+			params[k] = v
+		}
+		params[stringWT] = stringJavabin
+
+		copied := *request
+		copied.Params = params
+		request = &copied
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := (SearchParams{RequestHandler: request.Handler}).handler()
+
+	url := strings.Builder{}
+
+	url.Grow(len(s.coreURL) + len(stringBar)*3 + len(stringSolrBase) + len(instanceName) + len(handler))
+
+	url.WriteString(s.coreURL)
+	url.WriteString(stringBar)
+	url.WriteString(stringSolrBase)
+	url.WriteString(stringBar)
+	url.WriteString(instanceName)
+	url.WriteString(stringBar)
+	url.WriteString(handler)
+
+	raw, err := s.httpPostBody(url.String(), contentType, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decodeSearch(raw, request.hasFacets(), s.documentParser)
+
+}
+
+// hasFacets - the facet_fields must be parsed, the facet param may be a bool or a string
+func (request *JSONRequest) hasFacets() bool {
+
+	if len(request.Facet) > 0 {
+		return true
+	}
+
+	facet := request.Params[stringFacetParam]
+
+	return facet == true || facet == stringTrue
+
+}
+
+// parserJSONFacets - parses the facets of the JSON Facet API, nil when there are none
+func (s *Instance) parserJSONFacets(root responseNode) (map[string]interface{}, error) {
+
+	value, ok := root.Get(rawFacets)
+	if !ok || value.Kind() == jsonparser.Null {
+		return nil, nil
+	}
+
+	raw, err := value.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	facets := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &facets); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+
+}
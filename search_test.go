@@ -0,0 +1,80 @@
+package solr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONRequestPaging(t *testing.T) {
+
+	cases := []struct {
+		request  JSONRequest
+		expected string
+	}{
+		{JSONRequest{Query: "*:*"}, `{"query":"*:*"}`},
+		{JSONRequest{Query: "*:*", Limit: 5}, `{"query":"*:*","limit":5}`},
+		{JSONRequest{Query: "*:*", Offset: 20, Limit: 10}, `{"query":"*:*","offset":20,"limit":10}`},
+	}
+
+	for _, c := range cases {
+
+		body, err := json.Marshal(&c.request)
+		if assert.NoError(t, err) {
+			assert.Equal(t, c.expected, string(body))
+		}
+	}
+
+}
+
+func TestSearchJSONHandler(t *testing.T) {
+
+	var path string
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"responseHeader":{"status":0,"QTime":1},"response":{"numFound":0,"start":0,"docs":[]}}`))
+	}))
+	defer server.Close()
+
+	s := &Instance{coreURL: server.URL, httpPostClient: server.Client(), documentParser: &DefaultDocumentParser{}}
+
+	cases := map[string]string{
+		"":       "/solr/collection/select",
+		"/query": "/solr/collection/query",
+		"browse": "/solr/collection/browse",
+	}
+
+	for handler, expected := range cases {
+
+		_, err := s.SearchJSON(&JSONRequest{Query: "*:*", Handler: handler}, "collection")
+		if assert.NoError(t, err, handler) {
+			assert.Equal(t, expected, path, handler)
+			assert.Equal(t, map[string]interface{}{"query": "*:*"}, body, handler)
+		}
+	}
+}
+
+func TestJSONRequestHasFacets(t *testing.T) {
+
+	cases := []struct {
+		request  JSONRequest
+		expected bool
+	}{
+		{JSONRequest{}, false},
+		{JSONRequest{Params: map[string]interface{}{"df": "metric"}}, false},
+		{JSONRequest{Params: map[string]interface{}{"facet": false}}, false},
+		{JSONRequest{Params: map[string]interface{}{"facet": true}}, true},
+		{JSONRequest{Params: map[string]interface{}{"facet": "true"}}, true},
+		{JSONRequest{Facet: map[string]interface{}{"tags": map[string]interface{}{"type": "terms", "field": "tag_value"}}}, true},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.expected, c.request.hasFacets(), i)
+	}
+}
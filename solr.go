@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -275,23 +276,33 @@ func (s *Instance) Create(instanceName string) error {
 //Search A basic search in solr
 func (s *Instance) Search(params *SearchParams, instanceName string) (*Response, error) {
 
+	return s.search(params, instanceName, s.documentParser)
+}
+
+func (s *Instance) search(params *SearchParams, instanceName string, parser DocumentParser) (*Response, error) {
+
 	var res *Response
 
 	if params != nil {
-		var facet bool
-		var searchType, stringParams string
 
-		if params.toQueryString() != "" {
-			stringParams = params.toQueryString()
-		}
-		if params.BlockJoinFaceting {
-			searchType = stringFacet
-		} else {
-			searchType = stringSelect
+		if params.EDisMax != nil {
+
+			// the other query parsers would silently ignore the edismax params
+			if params.DefType != "" && params.DefType != edismaxParser {
+				return nil, fmt.Errorf("the edismax params require the edismax defType, got %s", params.DefType)
+			}
+
+			if err := params.EDisMax.Validate(); err != nil {
+				return nil, err
+			}
 		}
 
-		if len(params.Facets) > 0 {
-			facet = true
+		stringParams := params.toQueryString()
+		searchType := params.handler()
+		facet := params.hasFacets()
+
+		if s.responseFormat == FormatJavabin {
+			stringParams += wtJavabin
 		}
 
 		url := strings.Builder{}
@@ -305,14 +316,21 @@ func (s *Instance) Search(params *SearchParams, instanceName string) (*Response,
 		url.WriteString(instanceName)
 		url.WriteString(stringBar)
 		url.WriteString(searchType)
-		url.WriteString(stringParams)
 
-		raw, err := s.httpGet(url.String())
+		var raw []byte
+		var err error
+
+		if s.usePost(url.Len() + len(stringParams)) {
+			raw, err = s.httpPostBody(url.String(), contentTypeForm, strings.NewReader(strings.TrimPrefix(stringParams, stringAmpersand)))
+		} else {
+			url.WriteString(stringParams)
+			raw, err = s.httpGet(url.String())
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		res, err = s.Decode(raw, facet)
+		res, err = s.decodeSearch(raw, facet, parser)
 		if err != nil {
 			return nil, err
 		}
@@ -324,6 +342,17 @@ func (s *Instance) Search(params *SearchParams, instanceName string) (*Response,
 	return res, nil
 }
 
+// decodeSearch - decodes the search response in the format of the Instance
+func (s *Instance) decodeSearch(raw []byte, facet bool, parser DocumentParser) (*Response, error) {
+
+	if s.responseFormat == FormatJavabin {
+		return s.decodeJavabin(raw, facet, parser)
+	}
+
+	return s.decode(raw, facet, parser)
+
+}
+
 func getLen(x interface{}, count int) int {
 
 	xType := reflect.TypeOf(x)
@@ -358,56 +387,111 @@ func getLen(x interface{}, count int) int {
 
 }
 
-// UpdateDocument - post json on solr, if the postParams is passed it will be adding in the request. For deleting items you can use the post function using the json format: https://lucene.apache.org/solr/guide/6_6/uploading-data-with-index-handlers.html#UploadingDatawithIndexHandlers-SendingJSONUpdateCommands
+// UpdateDocument - post json on solr, if the postParams is passed it will be adding in the request. For deleting items use DeleteByID, DeleteByQuery or an UpdateRequest
 func (s *Instance) UpdateDocument(instanceName string, postParams map[string]string, payload interface{}) error {
 
-	pp := strings.Builder{}
+	return s.UpdateDocumentWithOptions(instanceName, postParams, payload, nil)
 
-	pp.Grow(len(s.coreURL) + getLen(postParams, len(stringAmpersand+stringEqual)) + len(stringBar)*2 + len(stringSolrBase) + len(instanceName) + len(stringUpdate) + len(stringCommitTrue) + len(wtJSON))
+}
 
-	pp.WriteString(s.coreURL)
-	pp.WriteString(stringBar)
-	pp.WriteString(stringSolrBase)
-	pp.WriteString(stringBar)
-	pp.WriteString(instanceName)
-	pp.WriteString(stringUpdate)
+// UpdateDocumentWithOptions - same as UpdateDocument using the commit options of this call, nil uses the Instance commit options
+func (s *Instance) UpdateDocumentWithOptions(instanceName string, postParams map[string]string, payload interface{}, commit *CommitOptions) error {
 
-	if len(postParams) > 0 {
-		for k, v := range postParams {
-			pp.WriteString(stringAmpersand)
-			pp.WriteString(url.QueryEscape(k))
-			pp.WriteString(stringEqual)
-			pp.WriteString(url.QueryEscape(v))
-		}
+	if commit == nil {
+		commit = s.commitOptions
 	}
 
-	pp.WriteString(stringCommitTrue)
-	pp.WriteString(wtJSON)
-
 	writer, err := s.documentWriter.Writer(payload)
 	if err != nil {
 		return err
 	}
 
-	resp, err := s.httpPost(pp.String(), contentType, string(writer))
+	handler, params, writerContentType := s.updateHandler(postParams)
+
+	return s.postUpdate(s.updateURL(instanceName, handler, params, commit), writerContentType, writer)
+}
+
+// updateHandler - returns the handler, params and Content-Type of the DocumentWriter
+func (s *Instance) updateHandler(postParams map[string]string) (string, map[string]string, string) {
+
+	writer, ok := s.documentWriter.(UpdateHandlerWriter)
+	if !ok {
+		return stringUpdate, postParams, contentType
+	}
+
+	params := writer.HandlerParams()
+	if len(params) == 0 {
+		return writer.Handler() + stringQuestion, postParams, writer.ContentType()
+	}
+
+	merged := make(map[string]string, len(params)+len(postParams))
+	for k, v := range params {
+		merged[k] = v
+	}
+	for k, v := range postParams {
+		merged[k] = v
+	}
+
+	return writer.Handler() + stringQuestion, merged, writer.ContentType()
+
+}
+
+func (s *Instance) postUpdate(url, contentType string, body []byte) error {
+
+	resp, err := s.httpPost(url, contentType, string(body))
 	if err != nil {
 		return err
 	}
 
+	return decodeUpdateResponse([]byte(resp))
+}
+
+// decodeUpdateResponse - returns the solr error of an update response, if any
+func decodeUpdateResponse(resp []byte) error {
+
 	var response responseRaw
 
-	err = json.Unmarshal([]byte(resp), &response)
+	err := json.Unmarshal(resp, &response)
 	if err != nil {
 		return err
 	}
 
-	if response.Header.Status != 0 {
-		return fmt.Errorf("Solr returned status %d", response.Header.Status)
+	if response.Header.Status != 0 || len(response.Header.Errors) > 0 {
+		return newSolrError(&response)
 	}
 
 	return nil
 }
 
+func (s *Instance) updateURL(instanceName, handler string, postParams map[string]string, commit *CommitOptions) string {
+
+	pp := strings.Builder{}
+
+	pp.Grow(len(s.coreURL) + getLen(postParams, len(stringAmpersand+stringEqual)) + len(stringBar)*2 + len(stringSolrBase) + len(instanceName) + len(handler) + len(stringCommit) + len(wtJSON))
+
+	pp.WriteString(s.coreURL)
+	pp.WriteString(stringBar)
+	pp.WriteString(stringSolrBase)
+	pp.WriteString(stringBar)
+	pp.WriteString(instanceName)
+	pp.WriteString(handler)
+
+	if len(postParams) > 0 {
+		for k, v := range postParams {
+			pp.WriteString(stringAmpersand)
+			pp.WriteString(url.QueryEscape(k))
+			pp.WriteString(stringEqual)
+			pp.WriteString(url.QueryEscape(v))
+		}
+	}
+
+	commit.writeQueryString(&pp)
+	pp.WriteString(wtJSON)
+
+	return pp.String()
+
+}
+
 func (s *Instance) httpPost(url, contentType, body string) (string, error) {
 
 	payload := bytes.NewBufferString(body)
@@ -426,6 +510,17 @@ func (s *Instance) httpPost(url, contentType, body string) (string, error) {
 
 }
 
+func (s *Instance) httpPostBody(url, contentType string, body io.Reader) ([]byte, error) {
+
+	res, err := s.httpPostClient.Post(url, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return readResponse(res)
+
+}
+
 func (s *Instance) httpGet(url string) ([]byte, error) {
 
 	client := s.httpGetClient
@@ -434,6 +529,13 @@ func (s *Instance) httpGet(url string) ([]byte, error) {
 		return nil, err
 	}
 
+	return readResponse(res)
+
+}
+
+// readResponse - reads the body of the response, returning an error when the status is not 2xx
+func readResponse(res *http.Response) ([]byte, error) {
+
 	defer res.Body.Close()
 
 	body, err := ioutil.ReadAll(res.Body)
@@ -454,3 +556,23 @@ func (s *Instance) httpGet(url string) ([]byte, error) {
 	return body, nil
 
 }
+
+// EscapeQueryChars - escapes the characters with special meaning for the solr query parser
+func EscapeQueryChars(value string) string {
+
+	escaped := strings.Builder{}
+	escaped.Grow(len(value) * 2)
+
+	for _, c := range value {
+
+		switch c {
+		case '\\', '+', '-', '!', '(', ')', ':', '^', '[', ']', '"', '{', '}', '~', '*', '?', '|', '&', ';', '/', ' ', '\t':
+			escaped.WriteRune('\\')
+		}
+
+		escaped.WriteRune(c)
+	}
+
+	return escaped.String()
+
+}
@@ -0,0 +1,239 @@
+package solr
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// SpellcheckParams - Params for the spellcheck component, for more information visit https://lucene.apache.org/solr/guide/7_4/spell-checking.html
+type SpellcheckParams struct {
+	Q                      string //Q - the query to be checked, when empty solr checks the main query
+	Dictionary             string //Dictionary - the dictionary (spellchecker name) to be used
+	Count                  int    //Count - maximum number of suggestions per token
+	Collate                bool   //Collate - build collations (the "did you mean" queries)
+	MaxCollationTries      int    //MaxCollationTries - number of collations tested against the index
+	ExtendedResults        bool   //ExtendedResults - returns the frequencies and the correctlySpelled flag
+	CollateExtendedResults bool   //CollateExtendedResults - returns the hits and corrections per collation
+}
+
+func (params *SpellcheckParams) writeQueryString(qs *strings.Builder) {
+
+	qs.WriteString(stringSpellcheckTrue)
+
+	if params.Q != "" {
+		writeParam(qs, stringSpellcheckQ, params.Q)
+	}
+
+	if params.Dictionary != "" {
+		writeParam(qs, stringSpellcheckDictionary, params.Dictionary)
+	}
+
+	if params.Count > 0 {
+		writeParam(qs, stringSpellcheckCount, strconv.Itoa(params.Count))
+	}
+
+	if params.Collate {
+		qs.WriteString(stringSpellcheckCollateTrue)
+	}
+
+	if params.MaxCollationTries > 0 {
+		writeParam(qs, stringSpellcheckMaxCollationTries, strconv.Itoa(params.MaxCollationTries))
+	}
+
+	if params.ExtendedResults {
+		qs.WriteString(stringSpellcheckExtendedTrue)
+	}
+
+	if params.CollateExtendedResults {
+		qs.WriteString(stringSpellcheckCollateExtTrue)
+	}
+
+}
+
+//Spellcheck - spellcheck section from the solr response
+type Spellcheck struct {
+	CorrectlySpelled bool
+	Suggestions      []SpellcheckSuggestion
+	Collations       []SpellcheckCollation
+}
+
+//SpellcheckSuggestion - the suggestions for a misspelled token
+type SpellcheckSuggestion struct {
+	Token       string
+	NumFound    int64
+	StartOffset int64
+	EndOffset   int64
+	OrigFreq    int64
+	Words       []SpellcheckWord
+}
+
+//SpellcheckWord - a suggested word, the frequency is only filled with extended results
+type SpellcheckWord struct {
+	Word string
+	Freq int64
+}
+
+//SpellcheckCollation - a "did you mean" query, hits and corrections are only filled with collate extended results
+type SpellcheckCollation struct {
+	Query       string
+	Hits        int64
+	Corrections []SpellcheckCorrection
+}
+
+//SpellcheckCorrection - the correction applied to a token in a collation
+type SpellcheckCorrection struct {
+	Original   string
+	Correction string
+}
+
+func (s *Instance) parserSpellcheck(root responseNode) (*Spellcheck, error) {
+
+	value, ok := root.Get(rawSpellcheck)
+	if !ok {
+		return nil, nil
+	}
+
+	spellcheck := &Spellcheck{}
+
+	err := value.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		switch key {
+		case rawCorrectlySpelled:
+
+			spellcheck.CorrectlySpelled, err = value.Bool()
+
+		case rawSuggestions:
+
+			err = value.Each(func(token string, keyType jsonparser.ValueType, value responseNode) error {
+
+				suggestion, err := parseSpellcheckSuggestion(token, value)
+				if err != nil {
+					return err
+				}
+
+				spellcheck.Suggestions = append(spellcheck.Suggestions, suggestion)
+				return nil
+			})
+
+		case rawCollations:
+
+			err = value.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+				collation, err := parseSpellcheckCollation(value)
+				if err != nil {
+					return err
+				}
+
+				spellcheck.Collations = append(spellcheck.Collations, collation)
+				return nil
+			})
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return spellcheck, nil
+
+}
+
+func parseSpellcheckSuggestion(token string, node responseNode) (SpellcheckSuggestion, error) {
+
+	suggestion := SpellcheckSuggestion{Token: token}
+
+	err := node.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		switch key {
+		case rawNumFound:
+			suggestion.NumFound, err = value.Int()
+		case rawStartOffset:
+			suggestion.StartOffset, err = value.Int()
+		case rawEndOffset:
+			suggestion.EndOffset, err = value.Int()
+		case rawOrigFreq:
+			suggestion.OrigFreq, err = value.Int()
+		case rawSuggestion:
+			suggestion.Words, err = parseSpellcheckWords(value)
+		}
+
+		return err
+	})
+
+	return suggestion, err
+
+}
+
+// parseSpellcheckWords - the suggested words, strings or objects with the word and freq when extendedResults is set
+func parseSpellcheckWords(node responseNode) ([]SpellcheckWord, error) {
+
+	var words []SpellcheckWord
+
+	err := node.Items(func(value responseNode) error {
+
+		var err error
+
+		word := SpellcheckWord{}
+
+		if value.Kind() == jsonparser.String {
+			word.Word, err = value.Text()
+		} else if word.Word, err = nodeText(value, rawWord); err == nil {
+			word.Freq, err = nodeInt(value, rawFreq)
+		}
+
+		words = append(words, word)
+		return err
+	})
+
+	return words, err
+
+}
+
+// parseSpellcheckCollation - a collation, a string or an object with the hits and corrections when collateExtendedResults is set
+func parseSpellcheckCollation(node responseNode) (SpellcheckCollation, error) {
+
+	var err error
+
+	collation := SpellcheckCollation{}
+
+	if node.Kind() == jsonparser.String {
+		collation.Query, err = node.Text()
+		return collation, err
+	}
+
+	if collation.Query, err = nodeText(node, rawCollationQuery); err != nil {
+		return collation, err
+	}
+
+	if hits, ok := node.Get(rawHits); ok {
+		if collation.Hits, err = hits.Int(); err != nil {
+			return collation, err
+		}
+	}
+
+	corrections, ok := node.Get(rawMisspellingsAndCorrections)
+	if !ok {
+		return collation, nil
+	}
+
+	err = corrections.Each(func(original string, keyType jsonparser.ValueType, value responseNode) error {
+
+		correction, err := value.Text()
+		if err != nil {
+			return err
+		}
+
+		collation.Corrections = append(collation.Corrections, SpellcheckCorrection{Original: original, Correction: correction})
+		return nil
+	})
+
+	return collation, err
+
+}
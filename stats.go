@@ -0,0 +1,285 @@
+package solr
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+)
+
+// StatsParams - Params for the stats component, for more information visit https://lucene.apache.org/solr/guide/7_4/the-stats-component.html
+type StatsParams struct {
+	Fields []StatsFieldParams //Fields - the fields to compute statistics (stats.field)
+	Facets []string           //Facets - the fields used to break down the statistics (stats.facet)
+}
+
+// StatsFieldParams - a stats field and its local params
+type StatsFieldParams struct {
+	Field         string            //Field - the field name or a function
+	Key           string            //Key - renames the field in the response
+	Stats         []string          //Stats - computes only the listed statistics (ex: min, max, count)
+	CountDistinct bool              //CountDistinct - computes the exact distinct count and values
+	Cardinality   bool              //Cardinality - computes the approximated distinct count
+	Percentiles   []float64         //Percentiles - the percentiles to be computed
+	LocalParams   map[string]string //LocalParams - any other local param
+}
+
+func (params *StatsParams) writeQueryString(qs *strings.Builder) {
+
+	qs.WriteString(stringStatsTrue)
+
+	for i := 0; i < len(params.Fields); i++ {
+		writeParam(qs, stringStatsField, params.Fields[i].String())
+	}
+
+	for i := 0; i < len(params.Facets); i++ {
+		writeParam(qs, stringStatsFacet, params.Facets[i])
+	}
+
+}
+
+// String - renders the stats field with its local params
+func (field StatsFieldParams) String() string {
+
+	var localParams []string
+
+	for i := 0; i < len(field.Stats); i++ {
+		localParams = append(localParams, field.Stats[i]+stringEqual+stringTrue)
+	}
+
+	if field.CountDistinct {
+		localParams = append(localParams, rawCountDistinct+stringEqual+stringTrue)
+	}
+
+	if field.Cardinality {
+		localParams = append(localParams, rawCardinality+stringEqual+stringTrue)
+	}
+
+	if len(field.Percentiles) > 0 {
+
+		percentiles := make([]string, len(field.Percentiles))
+		for i := 0; i < len(field.Percentiles); i++ {
+			percentiles[i] = strconv.FormatFloat(field.Percentiles[i], 'f', -1, 64)
+		}
+
+		localParams = append(localParams, stringPercentiles+stringEqual+stringQuote+strings.Join(percentiles, stringComma)+stringQuote)
+	}
+
+	if len(field.LocalParams) > 0 {
+
+		keys := make([]string, 0, len(field.LocalParams))
+		for k := range field.LocalParams {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			localParams = append(localParams, k+stringEqual+field.LocalParams[k])
+		}
+	}
+
+	if field.Key != "" {
+		localParams = append(localParams, stringKey+stringEqual+field.Key)
+	}
+
+	if len(localParams) == 0 {
+		return field.Field
+	}
+
+	return stringLocalParams + strings.Join(localParams, stringSpace) + stringLocalParamsEnd + field.Field
+
+}
+
+//FieldStats - statistics of a field, Min, Max, Sum and Mean are float64 for numeric fields and time.Time for date fields
+type FieldStats struct {
+	Min            interface{}
+	Max            interface{}
+	Count          int64
+	Missing        int64
+	Sum            interface{}
+	SumOfSquares   float64
+	Mean           interface{}
+	Stddev         float64
+	CountDistinct  int64
+	DistinctValues []interface{}
+	Cardinality    int64
+	Percentiles    []StatsPercentile
+	Facets         map[string]map[string]*FieldStats //Facets - the statistics by stats.facet field and value
+}
+
+//StatsPercentile - a computed percentile
+type StatsPercentile struct {
+	Percentile float64
+	Value      interface{}
+}
+
+func (s *Instance) parserStats(root responseNode) (map[string]*FieldStats, error) {
+
+	fields, ok := root.Get(rawStats, rawStatsFields)
+	if !ok {
+		return nil, nil
+	}
+
+	var stats map[string]*FieldStats
+
+	err := fields.Each(func(name string, keyType jsonparser.ValueType, value responseNode) error {
+
+		if stats == nil {
+			stats = map[string]*FieldStats{}
+		}
+
+		if value.Kind() != jsonparser.Object {
+			stats[name] = nil
+			return nil
+		}
+
+		var err error
+		stats[name], err = parseFieldStats(value)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+
+}
+
+func parseFieldStats(node responseNode) (*FieldStats, error) {
+
+	stats := &FieldStats{}
+
+	err := node.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		switch key {
+		case rawMin:
+			stats.Min, err = parseStatsValue(value)
+		case rawMax:
+			stats.Max, err = parseStatsValue(value)
+		case rawCount:
+			stats.Count, err = value.Int()
+		case rawMissing:
+			stats.Missing, err = value.Int()
+		case rawSum:
+			stats.Sum, err = parseStatsValue(value)
+		case rawSumOfSquares:
+			stats.SumOfSquares, err = value.Float()
+		case rawMean:
+			stats.Mean, err = parseStatsValue(value)
+		case rawStddev:
+			stats.Stddev, err = value.Float()
+		case rawCountDistinct:
+			stats.CountDistinct, err = value.Int()
+		case rawCardinality:
+			stats.Cardinality, err = value.Int()
+		case rawDistinctValues:
+			stats.DistinctValues, err = parseStatsValues(value)
+		case rawPercentiles:
+			stats.Percentiles, err = parseStatsPercentiles(value)
+		case rawFacets:
+			stats.Facets, err = parseStatsFacets(value)
+		}
+
+		return err
+	})
+
+	return stats, err
+
+}
+
+func parseStatsFacets(node responseNode) (map[string]map[string]*FieldStats, error) {
+
+	facets := map[string]map[string]*FieldStats{}
+
+	err := node.Each(func(field string, keyType jsonparser.ValueType, value responseNode) error {
+
+		facets[field] = map[string]*FieldStats{}
+
+		return value.Each(func(name string, keyType jsonparser.ValueType, value responseNode) error {
+
+			var err error
+			facets[field][name], err = parseFieldStats(value)
+			return err
+		})
+	})
+
+	return facets, err
+
+}
+
+func parseStatsPercentiles(node responseNode) ([]StatsPercentile, error) {
+
+	var percentiles []StatsPercentile
+
+	err := node.Each(func(key string, keyType jsonparser.ValueType, value responseNode) error {
+
+		var err error
+
+		percentile := StatsPercentile{}
+
+		if percentile.Percentile, err = strconv.ParseFloat(key, 64); err != nil {
+			return err
+		}
+
+		if percentile.Value, err = parseStatsValue(value); err != nil {
+			return err
+		}
+
+		percentiles = append(percentiles, percentile)
+		return nil
+	})
+
+	return percentiles, err
+
+}
+
+func parseStatsValues(node responseNode) ([]interface{}, error) {
+
+	var values []interface{}
+
+	err := node.Items(func(value responseNode) error {
+
+		v, err := parseStatsValue(value)
+		values = append(values, v)
+		return err
+	})
+
+	return values, err
+
+}
+
+// parseStatsValue - returns float64 for numbers, time.Time for dates and string for other values
+func parseStatsValue(node responseNode) (interface{}, error) {
+
+	switch node.Kind() {
+	case jsonparser.Number:
+
+		return node.Float()
+
+	case jsonparser.String:
+
+		value, err := node.Text()
+		if err != nil {
+			return nil, err
+		}
+
+		if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return date, nil
+		}
+
+		return value, nil
+
+	case jsonparser.Boolean:
+
+		return node.Bool()
+
+	default:
+
+		return nil, nil
+	}
+
+}
@@ -0,0 +1,188 @@
+package solr
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+)
+
+// StreamOptions - options of UpdateDocumentStream
+type StreamOptions struct {
+	Commit    *CommitOptions //Commit - commit options of the update, nil uses the Instance commit options
+	Gzip      bool           //Gzip - compresses the body with Content-Encoding: gzip, solr must be configured to accept gzipped requests
+	GzipLevel *int           //GzipLevel - the compress/gzip level, ex: gzip.NoCompression, nil uses gzip.DefaultCompression
+}
+
+// UpdateDocumentStream - same as UpdateDocument but the DocumentWriter writes the payload straight into the request body,
+// the writer must implement StreamDocumentWriter or the payload is written in memory as usual
+func (s *Instance) UpdateDocumentStream(instanceName string, postParams map[string]string, payload interface{}, options *StreamOptions) error {
+
+	if options == nil {
+		options = &StreamOptions{}
+	}
+
+	commit := options.Commit
+	if commit == nil {
+		commit = s.commitOptions
+	}
+
+	level, err := options.gzipLevel()
+	if err != nil {
+		return err
+	}
+
+	handler, params, writerContentType := s.updateHandler(postParams)
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(s.streamDocuments(writer, payload, options.Gzip, level))
+	}()
+
+	request, err := http.NewRequest(http.MethodPost, s.updateURL(instanceName, handler, params, commit), reader)
+	if err != nil {
+		reader.CloseWithError(err)
+		return err
+	}
+
+	request.Header.Set(headerContentType, writerContentType)
+	if options.Gzip {
+		request.Header.Set(headerContentEncoding, encodingGzip)
+	}
+
+	// the client closes the request body, stopping the writer if the request fails
+	res, err := s.httpPostClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	err = decodeUpdateResponse(body)
+	if _, ok := err.(*json.SyntaxError); ok && (res.StatusCode < 200 || res.StatusCode > 299) {
+		return fmt.Errorf("HTTP Status: %s", res.Status)
+	}
+
+	return err
+
+}
+
+// gzipLevel - the GzipLevel, validated when the body is compressed
+func (options *StreamOptions) gzipLevel() (int, error) {
+
+	if options.GzipLevel == nil {
+		return gzip.DefaultCompression, nil
+	}
+
+	level := *options.GzipLevel
+
+	if options.Gzip && (level < gzip.HuffmanOnly || level > gzip.BestCompression) {
+		return 0, fmt.Errorf("invalid gzip level: %d", level)
+	}
+
+	return level, nil
+
+}
+
+// streamDocuments - writes the payload to the output, compressing it if requested
+func (s *Instance) streamDocuments(output io.Writer, payload interface{}, compress bool, level int) error {
+
+	var zipper *gzip.Writer
+
+	if compress {
+
+		var err error
+		if zipper, err = gzip.NewWriterLevel(output, level); err != nil {
+			return err
+		}
+
+		output = zipper
+	}
+
+	buffer := bufio.NewWriter(output)
+
+	if streamer, ok := s.documentWriter.(StreamDocumentWriter); ok {
+
+		if err := streamer.Stream(buffer, payload); err != nil {
+			return err
+		}
+
+	} else {
+
+		raw, err := s.documentWriter.Writer(payload)
+		if err != nil {
+			return err
+		}
+
+		if _, err := buffer.Write(raw); err != nil {
+			return err
+		}
+	}
+
+	if err := buffer.Flush(); err != nil {
+		return err
+	}
+
+	if zipper != nil {
+		return zipper.Close()
+	}
+
+	return nil
+
+}
+
+// streamJSONArray - writes a slice payload as a JSON array encoding one element at a time straight to the output,
+// so only the current document is held in memory, other payloads are encoded as a whole,
+// convert returns the value written for each element
+func streamJSONArray(output io.Writer, payload interface{}, convert func(index int, elem reflect.Value) (interface{}, error)) error {
+
+	encoder := json.NewEncoder(output)
+
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	// a nil slice is written as null and a []byte or json.RawMessage is already serialized
+	if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || (value.Kind() == reflect.Slice && value.IsNil()) ||
+		value.Type().Elem().Kind() == reflect.Uint8 {
+		return encoder.Encode(payload)
+	}
+
+	if _, err := io.WriteString(output, stringArrayStart); err != nil {
+		return err
+	}
+
+	for i := 0; i < value.Len(); i++ {
+
+		if i > 0 {
+			if _, err := io.WriteString(output, stringComma); err != nil {
+				return err
+			}
+		}
+
+		document, err := convert(i, value.Index(i))
+		if err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(document); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(output, stringArrayEnd+stringNewLine)
+
+	return err
+
+}
@@ -3,8 +3,10 @@ package solr
 import (
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // The *Raw structs are used to unmarshall the JSON from Solr
@@ -16,6 +18,15 @@ type headerRaw struct {
 	// Use interface{} because some params are strings and
 	// others (e.g. fq) are arrays of strings.
 	Params map[string]interface{} `json:"params"`
+	// The commands rejected by the TolerantUpdateProcessor
+	Errors    []documentErrorRaw `json:"errors"`
+	MaxErrors int                `json:"maxErrors"`
+}
+
+type documentErrorRaw struct {
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
 }
 
 type dataRaw struct {
@@ -28,29 +39,40 @@ type dataRaw struct {
 type DocumentRaw map[string]interface{}
 
 type errorRaw struct {
+	Msg   string `json:"msg"`
 	Trace string `json:"trace"`
 	Code  int    `json:"code"`
 }
 
 type responseRaw struct {
-	Header      headerRaw      `json:"responseHeader"`
-	Data        dataRaw        `json:"response"`
-	Error       errorRaw       `json:"error"`
-	FacetCounts facetCountsRaw `json:"facet_counts"`
-}
-
-type facetCountsRaw struct {
-	Queries interface{}              `json:"facet_queries"`
-	Fields  map[string][]interface{} `json:"facet_fields"`
+	Header headerRaw `json:"responseHeader"`
+	Data   dataRaw   `json:"response"`
+	Error  errorRaw  `json:"error"`
 }
 
 //Response - response data from solr instance
 type Response struct {
-	Status   int64        `json:"status,omitempty"`
-	QTime    int64        `json:"Qtime,omitempty"`
-	NumFound int64        `json:"numFound,omitempty"`
-	Docs     interface{}  `json:"Docs,omitempty"`
-	Facets   []FacetField `json:"Facets,omitempty"`
+	Status     int64                  `json:"status,omitempty"`
+	QTime      int64                  `json:"Qtime,omitempty"`
+	NumFound   int64                  `json:"numFound,omitempty"`
+	Docs       interface{}            `json:"Docs,omitempty"`
+	Facets     []FacetField           `json:"Facets,omitempty"`
+	Spellcheck *Spellcheck            `json:"Spellcheck,omitempty"`
+	Stats      map[string]*FieldStats `json:"Stats,omitempty"`
+	Debug      *Debug                 `json:"Debug,omitempty"`
+	Header     *ResponseHeader        `json:"Header,omitempty"`
+	JSONFacets map[string]interface{} `json:"JSONFacets,omitempty"` //JSONFacets - the facets of the JSON Facet API
+}
+
+//ResponseHeader - the response header and the other search metadata
+type ResponseHeader struct {
+	Start                  int64               `json:"start"`
+	MaxScore               float64             `json:"maxScore,omitempty"`               //MaxScore - returned when the score is in the fl param
+	PartialResults         bool                `json:"partialResults,omitempty"`         //PartialResults - the search was truncated by timeAllowed or a shard failed with shards.tolerant
+	ZkConnected            bool                `json:"zkConnected,omitempty"`            //ZkConnected - false when the node lost the zookeeper connection, solr cloud only
+	SegmentTerminatedEarly bool                `json:"segmentTerminatedEarly,omitempty"` //SegmentTerminatedEarly - the search was terminated early by segmentTerminateEarly
+	Params                 map[string][]string `json:"params,omitempty"`                 //Params - the params echoed by solr, depends on the echoParams param
+	NextCursorMark         string              `json:"nextCursorMark,omitempty"`         //NextCursorMark - the cursorMark of the next page
 }
 
 //FacetField - struct for facets
@@ -61,8 +83,9 @@ type FacetField struct {
 
 //FacetValue - struct for facets name and value
 type FacetValue struct {
-	Name  string
-	Value int64
+	Name    string
+	Value   int64
+	Missing bool //Missing - the count of the documents without the field (facet.missing), the Name is empty
 }
 
 // Instance The main class to instance solr
@@ -76,18 +99,57 @@ type Instance struct {
 	listCollectionURL string
 	httpGetClient     *http.Client
 	httpPostClient    *http.Client
+	commitOptions     *CommitOptions
+	responseFormat    ResponseFormat
+	searchMethod      SearchMethod
+	maxURLLength      int
 }
 
 // SearchParams - Params for solr queries
 type SearchParams struct {
-	Q                 string
-	FL                string
-	FilterQueries     []string
-	BlockJoinFaceting bool
-	Sort              string
-	Facets            map[string]string
-	Rows              int
-	Start             int
+	Q                     string
+	FL                    string
+	FilterQueries         []string
+	BlockJoinFaceting     bool
+	Sort                  string
+	Facets                map[string]string
+	Rows                  int
+	Start                 int
+	Spellcheck            *SpellcheckParams
+	Stats                 *StatsParams
+	Debug                 *DebugParams
+	CursorMark            string         //CursorMark - deep paging, "*" for the first page and Response.Header.NextCursorMark for the next ones, requires a Sort on the id
+	RequestHandler        string         //RequestHandler - the handler path, ex: /query, /browse, default /select or /bjqfacet with the BlockJoinFaceting
+	DF                    string         //DF - the default field of the query
+	QOp                   string         //QOp - the default operator of the query, AND or OR
+	DefType               string         //DefType - the query parser, ex: edismax
+	TimeAllowed           time.Duration  //TimeAllowed - the search is truncated after this time, rounded up to milliseconds, and the Response.Header.PartialResults is set, zero disables it
+	SegmentTerminateEarly bool           //SegmentTerminateEarly - stops searching each segment early when the sort matches the index sort
+	Shards                []string       //Shards - the shards or collections to search, ex: localhost:8983/solr/core1
+	Params                url.Values     //Params - any other params, written after the ones above
+	EDisMax               *EDisMaxParams //EDisMax - the edismax params, sets the DefType to edismax when it is empty, any other DefType is an error
+}
+
+// handler - the path of the request handler, ending with the query string separator
+func (params SearchParams) handler() string {
+
+	if params.RequestHandler != "" {
+		return strings.Trim(params.RequestHandler, stringBar) + stringQuestion
+	}
+
+	if params.BlockJoinFaceting {
+		return stringFacet
+	}
+
+	return stringSelect
+
+}
+
+// hasFacets - the facet_fields must be parsed
+func (params SearchParams) hasFacets() bool {
+
+	return len(params.Facets) > 0 || params.Params.Get(stringFacetParam) == stringTrue
+
 }
 
 func (params SearchParams) toQueryString() string {
@@ -114,11 +176,19 @@ func (params SearchParams) toQueryString() string {
 
 		qs.WriteString(stringFacetTrue)
 
-		for k, v := range params.Facets {
+		// sorted to write the same query string for the same params
+		keys := make([]string, 0, len(params.Facets))
+		for k := range params.Facets {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
 
+			qs.WriteString(stringAmpersand)
 			qs.WriteString(url.QueryEscape(k))
 			qs.WriteString(stringEqual)
-			qs.WriteString(url.QueryEscape(v))
+			qs.WriteString(url.QueryEscape(params.Facets[k]))
 
 		}
 
@@ -145,6 +215,81 @@ func (params SearchParams) toQueryString() string {
 
 	}
 
+	if params.Spellcheck != nil {
+
+		params.Spellcheck.writeQueryString(&qs)
+
+	}
+
+	if params.Stats != nil {
+
+		params.Stats.writeQueryString(&qs)
+
+	}
+
+	if params.Debug != nil {
+
+		params.Debug.writeQueryString(&qs)
+
+	}
+
+	if params.CursorMark != "" {
+
+		writeParam(&qs, stringCursorMark, params.CursorMark)
+
+	}
+
+	if params.DF != "" {
+
+		writeParam(&qs, stringDF, params.DF)
+
+	}
+
+	if params.QOp != "" {
+
+		writeParam(&qs, stringQOp, params.QOp)
+
+	}
+
+	if params.DefType != "" {
+
+		writeParam(&qs, stringDefType, params.DefType)
+
+	}
+
+	if params.EDisMax != nil {
+
+		params.EDisMax.writeQueryString(&qs, params.DefType == "")
+
+	}
+
+	if params.TimeAllowed > 0 {
+
+		// rounded up, a timeAllowed of zero would disable the limit
+		qs.WriteString(stringTimeAllowed)
+		qs.WriteString(strconv.FormatInt(int64((params.TimeAllowed+time.Millisecond-1)/time.Millisecond), 10))
+
+	}
+
+	if params.SegmentTerminateEarly {
+
+		qs.WriteString(stringSegmentTerminateEarlyTrue)
+
+	}
+
+	if len(params.Shards) > 0 {
+
+		writeParam(&qs, stringShards, strings.Join(params.Shards, stringComma))
+
+	}
+
+	if len(params.Params) > 0 {
+
+		qs.WriteString(stringAmpersand)
+		qs.WriteString(params.Params.Encode())
+
+	}
+
 	qs.WriteString(stringStart)
 	qs.WriteString(strconv.Itoa(params.Start))
 
@@ -155,6 +300,13 @@ func (params SearchParams) toQueryString() string {
 
 }
 
+func writeParam(qs *strings.Builder, param, value string) {
+
+	qs.WriteString(param)
+	qs.WriteString(url.QueryEscape(value))
+
+}
+
 //DeleteResponse - response delete
 type DeleteResponse struct {
 	ResponseHeader struct {
@@ -0,0 +1,70 @@
+package solr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchParamsFacetQueryString(t *testing.T) {
+
+	params := SearchParams{
+		Q:      "*:*",
+		Facets: map[string]string{"facet.field": "tag_value", "facet.limit": "5"},
+		Rows:   10,
+	}
+
+	assert.Equal(t, "&facet=true&facet.field=tag_value&facet.limit=5&q=%2A%3A%2A&start=0&rows=10", params.toQueryString())
+
+	params.Facets = map[string]string{"facet.mincount": "1"}
+
+	assert.Equal(t, "&facet=true&facet.mincount=1&q=%2A%3A%2A&start=0&rows=10", params.toQueryString())
+}
+
+func TestSearchParamsTimeAllowed(t *testing.T) {
+
+	cases := map[time.Duration]string{
+		time.Nanosecond:                    "&timeAllowed=1&",
+		500 * time.Microsecond:             "&timeAllowed=1&",
+		time.Millisecond:                   "&timeAllowed=1&",
+		time.Millisecond + time.Nanosecond: "&timeAllowed=2&",
+		2 * time.Second:                    "&timeAllowed=2000&",
+	}
+
+	for timeAllowed, expected := range cases {
+		assert.Contains(t, (SearchParams{TimeAllowed: timeAllowed}).toQueryString(), expected, timeAllowed.String())
+	}
+
+	assert.NotContains(t, (SearchParams{}).toQueryString(), "timeAllowed")
+}
+
+func TestSearchParamsEDisMaxQueryString(t *testing.T) {
+
+	params := SearchParams{
+		Q: "cpu",
+		EDisMax: &EDisMaxParams{
+			QF:    []string{"metric^2 ", "tag_value"},
+			MM:    "75%",
+			BF:    []string{"log(popularity)"},
+			Boost: []string{"recip(ms(NOW,creation_date),3.16e-11,1,1)"},
+			PS:    2,
+		},
+	}
+
+	assert.Equal(t, "&q=cpu&defType=edismax&qf=metric%5E2+tag_value&mm=75%25&bf=log%28popularity%29"+
+		"&boost=recip%28ms%28NOW%2Ccreation_date%29%2C3.16e-11%2C1%2C1%29&ps=2&start=0&rows=0", params.toQueryString())
+
+	params.DefType = edismaxParser
+
+	assert.Equal(t, "&q=cpu&defType=edismax&qf=metric%5E2+tag_value&mm=75%25&bf=log%28popularity%29"+
+		"&boost=recip%28ms%28NOW%2Ccreation_date%29%2C3.16e-11%2C1%2C1%29&ps=2&start=0&rows=0", params.toQueryString())
+}
+
+func TestSearchEDisMaxDefType(t *testing.T) {
+
+	s := &Instance{}
+
+	_, err := s.Search(&SearchParams{Q: "cpu", DefType: "lucene", EDisMax: &EDisMaxParams{QF: []string{"metric"}}}, "collection")
+	assert.EqualError(t, err, "the edismax params require the edismax defType, got lucene")
+}
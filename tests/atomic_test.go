@@ -0,0 +1,69 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestAtomicUpdate(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2"}, false)
+
+	err := defaultInstance.UpdateAtomic(keyset, nil,
+		solr.NewAtomicUpdate(expected[0].ID).Set("tag_value", "host10").Set("tsid", "tsid1"),
+		solr.NewAtomicUpdate(expected[1].ID).Set("tag_key", "service").Set("tag_value", "solr"),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := defaultInstance.Get(keyset, expected[0].ID, expected[1].ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	docs := res.Docs.([]solr.DocumentRaw)
+	if !assert.Len(t, docs, 2) {
+		return
+	}
+
+	if !assert.Equal(t, metric, docs[0]["metric"], "fields not updated must be kept") {
+		return
+	}
+
+	if !assert.Equal(t, "host10", docs[0]["tag_value"]) {
+		return
+	}
+
+	if !assert.Equal(t, "tsid1", docs[0]["tsid"]) {
+		return
+	}
+
+	if !assert.Equal(t, "service", docs[1]["tag_key"]) {
+		return
+	}
+
+	if !assert.Equal(t, "solr", docs[1]["tag_value"]) {
+		return
+	}
+
+	err = defaultInstance.UpdateAtomic(keyset, nil, solr.NewAtomicUpdate(expected[0].ID).Set("tsid", nil))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err = defaultInstance.Get(keyset, expected[0].ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotContains(t, res.Docs.([]solr.DocumentRaw)[0], "tsid")
+
+}
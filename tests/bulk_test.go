@@ -0,0 +1,99 @@
+package solr
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestBulkIndexer(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	var flushed, failed int64
+
+	indexer, err := defaultInstance.NewBulkIndexer(solr.BulkIndexerConfig{
+		Workers:       2,
+		MaxDocuments:  50,
+		BufferSize:    100,
+		FlushInterval: time.Second,
+		Commit:        &solr.CommitOptions{Mode: solr.CommitNone},
+		OnFlush: func(result *solr.BulkFlushResult) {
+			atomic.AddInt64(&flushed, int64(len(result.Documents)))
+		},
+		OnFailure: func(result *solr.BulkFlushResult) {
+			atomic.AddInt64(&failed, int64(len(result.Documents)))
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	producers := 4
+	docSize := 125
+
+	wg := sync.WaitGroup{}
+	wg.Add(producers)
+
+	for p := 0; p < producers; p++ {
+
+		go func(p int) {
+
+			defer wg.Done()
+
+			for i := 0; i < docSize; i++ {
+
+				err := indexer.Add(keyset, DefaultDocument{
+					ID:        fmt.Sprintf("%d-%d", p, i),
+					Metric:    metric,
+					Type:      "meta",
+					ParentDoc: true,
+					TagKey:    "host",
+					TagValue:  fmt.Sprintf("host%d", i),
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+		}(p)
+	}
+
+	wg.Wait()
+
+	if !assert.NoError(t, indexer.Close()) {
+		return
+	}
+
+	if !assert.Error(t, indexer.Add(keyset, DefaultDocument{}), "closed indexers must refuse documents") {
+		return
+	}
+
+	if !assert.Equal(t, int64(producers*docSize), atomic.LoadInt64(&flushed)) {
+		return
+	}
+
+	if !assert.Equal(t, int64(0), atomic.LoadInt64(&failed)) {
+		return
+	}
+
+	if !assert.NoError(t, defaultInstance.Commit(keyset, nil)) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{Q: "metric:" + metric}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(producers*docSize), res.NumFound)
+
+}
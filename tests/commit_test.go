@@ -0,0 +1,88 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestCommitOptions(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	inst := createInstance(&solr.CloudParams{
+		CollectionConfigName: "mycenae",
+	})
+
+	inst.SetCommitOptions(&solr.CommitOptions{Mode: solr.CommitNone})
+
+	newDoc := []DefaultDocument{{
+		ID:        "1",
+		Metric:    metric,
+		Type:      "meta",
+		ParentDoc: true,
+		TagKey:    "host",
+		TagValue:  "host1",
+	}}
+
+	err := inst.UpdateDocument(keyset, nil, newDoc)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := inst.Get(keyset, "1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(1), res.NumFound, "expected the uncommitted document by real-time get") {
+		return
+	}
+
+	err = inst.SoftCommit(keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	searchParams := &solr.SearchParams{
+		Q:    "metric:" + metric,
+		Rows: 10,
+	}
+
+	libres, err := inst.Search(searchParams, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(1), libres.NumFound) {
+		return
+	}
+
+	newDoc[0].ID = "2"
+
+	err = inst.UpdateDocumentWithOptions(keyset, nil, newDoc, &solr.CommitOptions{Mode: solr.CommitHard, NoWaitSearcher: true, ExpungeDeletes: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = inst.Commit(keyset, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	libres, err = inst.Search(searchParams, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(2), libres.NumFound) {
+		return
+	}
+
+	assert.NoError(t, inst.Optimize(keyset, 1))
+
+}
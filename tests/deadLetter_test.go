@@ -0,0 +1,154 @@
+package solr
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func readDeadLetters(t *testing.T, path string) []solr.DeadLetter {
+
+	file, err := os.Open(path)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	defer file.Close()
+
+	var letters []solr.DeadLetter
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+
+		letter := solr.DeadLetter{}
+		if assert.NoError(t, json.Unmarshal(scanner.Bytes(), &letter)) {
+			letters = append(letters, letter)
+		}
+	}
+
+	return letters
+}
+
+func TestBulkIndexerDeadLetter(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	file, err := ioutil.TempFile("", "deadletter")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	file.Close()
+	defer os.Remove(file.Name())
+
+	sink, err := solr.NewFileDeadLetterSink(file.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	indexer, err := defaultInstance.NewBulkIndexer(solr.BulkIndexerConfig{
+		MaxDocuments: 10,
+		Tolerant:     &solr.TolerantOptions{MaxErrors: solr.MaxErrorsUnlimited},
+		DeadLetter:   sink,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	docs := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	docs[2].CreationDate = "not a date"
+
+	for i := 0; i < len(docs); i++ {
+		assert.NoError(t, indexer.Add(keyset, docs[i]))
+	}
+
+	assert.NoError(t, indexer.Close())
+	assert.NoError(t, sink.Close())
+
+	letters := readDeadLetters(t, file.Name())
+	if !assert.Len(t, letters, 1) {
+		return
+	}
+
+	assert.Equal(t, keyset, letters[0].Collection)
+	assert.NotEmpty(t, letters[0].Error)
+
+	rejected := DefaultDocument{}
+	if assert.NoError(t, json.Unmarshal(letters[0].Document, &rejected)) {
+		assert.Equal(t, docs[2], rejected)
+	}
+
+	assert.Equal(t, int64(2), countDocuments(t, keyset, metric))
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	file, err := ioutil.TempFile("", "deadletter")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	file.Close()
+	defer os.Remove(file.Name())
+
+	sink, err := solr.NewFileDeadLetterSink(file.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	docs := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	docs[1].CreationDate = "not a date"
+
+	documents := make([]interface{}, len(docs))
+	for i := 0; i < len(docs); i++ {
+		documents[i] = docs[i]
+	}
+
+	letters, err := solr.NewDeadLetters(keyset, documents, defaultInstance.UpdateDocument(keyset, nil, docs))
+	if !assert.NoError(t, err) || !assert.Len(t, letters, len(docs)) {
+		return
+	}
+
+	for i := 0; i < len(letters); i++ {
+		assert.NoError(t, sink.Write(letters[i]))
+	}
+
+	assert.NoError(t, sink.Close())
+
+	failedFile, err := ioutil.TempFile("", "deadletter")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	failedFile.Close()
+	defer os.Remove(failedFile.Name())
+
+	failedSink, err := solr.NewFileDeadLetterSink(failedFile.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	replayed, err := defaultInstance.ReplayDeadLetters(file.Name(), &solr.ReplayOptions{
+		Tolerant: &solr.TolerantOptions{MaxErrors: solr.MaxErrorsUnlimited},
+		Sink:     failedSink,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.NoError(t, failedSink.Close())
+
+	assert.Len(t, readDeadLetters(t, failedFile.Name()), 1)
+	assert.Equal(t, int64(2), countDocuments(t, keyset, metric))
+}
@@ -0,0 +1,79 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestDebug(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	tags := make(map[string]string)
+	tags["host"] = "host"
+
+	expected, err := makeDocs(metric, keyset, "", tags, 3, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	searchParams := &solr.SearchParams{
+		Q:             "metric:" + metric,
+		FilterQueries: []string{"tag_key:host"},
+		Rows:          10,
+		Debug: &solr.DebugParams{
+			Debug:             []string{solr.DebugAll},
+			ExplainStructured: true,
+		},
+	}
+
+	res, err := defaultInstance.Search(searchParams, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.NotNil(t, res.Debug) {
+		return
+	}
+
+	if !assert.Equal(t, "metric:"+metric, res.Debug.RawQueryString) {
+		return
+	}
+
+	if !assert.Equal(t, []string{"tag_key:host"}, res.Debug.FilterQueries) {
+		return
+	}
+
+	if !assert.NotNil(t, res.Debug.Timing) {
+		return
+	}
+
+	if !assert.NotEmpty(t, res.Debug.Timing.Process) {
+		return
+	}
+
+	if !assert.Len(t, res.Debug.Explain, len(expected)) {
+		return
+	}
+
+	explain, ok := res.Debug.Explain[expected[0].ID]
+	if !assert.True(t, ok, "expected the explanation of document %s", expected[0].ID) {
+		return
+	}
+
+	if !assert.True(t, explain.Match) {
+		return
+	}
+
+	if !assert.NotEmpty(t, explain.String()) {
+		return
+	}
+
+	assert.Contains(t, res.Debug.String(), "parsed query: ")
+
+}
@@ -0,0 +1,78 @@
+package solr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestDeleteDocuments(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3", "host4", "host5"}, false)
+
+	err := defaultInstance.DeleteByID(keyset, expected[0].ID, expected[1].ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(3), countDocuments(t, keyset, metric)) {
+		return
+	}
+
+	err = defaultInstance.DeleteByQuery(keyset, "tag_value:host3")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(2), countDocuments(t, keyset, metric)) {
+		return
+	}
+
+	err = defaultInstance.DeleteByIDWithOptions(keyset, &solr.DeleteOptions{Version: 1234}, expected[3].ID)
+
+	var conflict *solr.VersionConflictError
+	if !assert.True(t, errors.As(err, &conflict), "expected a version conflict error: %v", err) {
+		return
+	}
+
+	request := solr.NewUpdateRequest().
+		Add(DefaultDocument{ID: "6", Metric: metric, Type: "meta", ParentDoc: true, TagKey: "host", TagValue: "host6"}).
+		DeleteByID(nil, expected[3].ID).
+		DeleteByQuery("tag_value:host5", nil).
+		Commit(nil)
+
+	err = defaultInstance.SendUpdateRequest(keyset, request)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{Q: "metric:" + metric, Rows: 10}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	docs := res.Docs.([]solr.DocumentRaw)
+	if !assert.Len(t, docs, 1) {
+		return
+	}
+
+	assert.Equal(t, "host6", docs[0]["tag_value"])
+
+}
+
+func countDocuments(t *testing.T, keyset, metric string) int64 {
+
+	res, err := defaultInstance.Search(&solr.SearchParams{Q: "metric:" + metric}, keyset)
+	if !assert.NoError(t, err) {
+		return -1
+	}
+
+	return res.NumFound
+}
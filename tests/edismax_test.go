@@ -0,0 +1,114 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestEDisMaxSearch(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q: metric + " host2",
+		EDisMax: &solr.EDisMaxParams{
+			QF:  []string{"metric", "tag_value^10"},
+			MM:  "1",
+			Tie: 0.1,
+			BQ:  []string{"tag_key:host^2"},
+			UF:  []string{"*"},
+		},
+		FL:   "*,score",
+		Rows: 10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(3), res.NumFound)
+
+	docs := res.Docs.([]solr.DocumentRaw)
+	if assert.Len(t, docs, 3) {
+		assert.Equal(t, "host2", docs[0]["tag_value"])
+	}
+
+	assert.Equal(t, []string{"edismax"}, res.Header.Params["defType"])
+	assert.Equal(t, []string{"metric tag_value^10"}, res.Header.Params["qf"])
+}
+
+func TestEDisMaxFilterQuery(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	edismax := &solr.EDisMaxParams{QF: []string{"tag_value^2", "tag_key"}, MM: "100%"}
+
+	fq, err := edismax.LocalParams("host1 host")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "{!edismax qf='tag_value^2 tag_key' mm='100%' v='host1 host'}", fq)
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:             "metric:" + metric,
+		FilterQueries: []string{fq},
+		Rows:          10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(1), res.NumFound)
+}
+
+func TestEDisMaxValidation(t *testing.T) {
+
+	invalid := []*solr.EDisMaxParams{
+		{QF: []string{"title^"}},
+		{QF: []string{"title^high"}},
+		{QF: []string{"title^2^3"}},
+		{QF: []string{" "}},
+		{PF: []string{"title~^2"}},
+		{PF2: []string{"title:2"}},
+		{Tie: 1.5},
+		{PS: -1},
+	}
+
+	for _, params := range invalid {
+
+		assert.Error(t, params.Validate())
+
+		_, err := defaultInstance.Search(&solr.SearchParams{Q: "*:*", EDisMax: params}, "invalid")
+		assert.Error(t, err)
+	}
+
+	valid := &solr.EDisMaxParams{
+		QF:  []string{"title^2.5 body", "tag_value^.5"},
+		PF:  []string{"title~2^10"},
+		PF3: []string{"body~5"},
+		UF:  []string{"* -title"},
+	}
+
+	assert.NoError(t, valid.Validate())
+
+	_, err := defaultInstance.Search(&solr.SearchParams{Q: "*:*", DefType: "lucene", EDisMax: valid}, "invalid")
+	assert.EqualError(t, err, "the edismax params require the edismax defType, got lucene")
+}
@@ -0,0 +1,42 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestFacetMissing(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	docs := []interface{}{}
+	for _, doc := range makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2"}, false) {
+		docs = append(docs, doc)
+	}
+
+	docs = append(docs, map[string]interface{}{"id": "untagged", "metric": metric, "type": "meta"})
+
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, docs)) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:      "metric:" + metric,
+		Facets: map[string]string{"facet.field": "tag_key", "facet.missing": "true"},
+		Rows:   0,
+	}, keyset)
+	if !assert.NoError(t, err) || !assert.Len(t, res.Facets, 1) {
+		return
+	}
+
+	assert.Equal(t, "tag_key", res.Facets[0].Name)
+	assert.Equal(t, []solr.FacetValue{
+		{Name: "host", Value: 2},
+		{Value: 1, Missing: true},
+	}, res.Facets[0].List)
+}
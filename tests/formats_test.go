@@ -0,0 +1,88 @@
+package solr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func createWriterInstance(writer solr.DocumentWriter) *solr.Instance {
+
+	inst, err := solr.NewCloud(getSolrAddress(), time.Duration(20*time.Second), time.Duration(20*time.Second), 100, 100, &solr.CloudParams{CollectionConfigName: "mycenae"}, &solr.DefaultDocumentParser{}, writer)
+	if err != nil {
+		panic(err)
+	}
+
+	return inst
+}
+
+func TestUpdateFormats(t *testing.T) {
+
+	writers := map[string]solr.DocumentWriter{
+		"csv":        &solr.CSVDocumentWriter{Separator: ';'},
+		"csv_fields": &solr.CSVDocumentWriter{Fields: []string{"id", "metric", "type", "tag_key", "tag_value", "creation_date"}, SkipHeader: true},
+		"xml":        &solr.XMLDocumentWriter{},
+		"json_lines": &solr.JSONLinesDocumentWriter{},
+	}
+
+	for name, writer := range writers {
+
+		keyset := randomKeyset()
+		metric := randomMetric()
+
+		createCollection(t, keyset)
+
+		inst := createWriterInstance(writer)
+
+		expected := []DefaultDocument{}
+		for i, host := range []string{"host1", "host2", "host3"} {
+			expected = append(expected, DefaultDocument{
+				ID:           string(rune('1' + i)),
+				Metric:       metric,
+				Type:         "meta",
+				ParentDoc:    true,
+				TagKey:       "host",
+				TagValue:     host,
+				CreationDate: time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+			})
+		}
+
+		err := inst.UpdateDocument(keyset, nil, expected)
+		if !assert.NoError(t, err, "writer %s", name) {
+			return
+		}
+
+		res, err := defaultInstance.Search(&solr.SearchParams{Q: "metric:" + metric, Sort: "id asc", Rows: 10}, keyset)
+		if !assert.NoError(t, err, "writer %s", name) {
+			return
+		}
+
+		if !testDocumentRaw(t, expected, res.Docs) {
+			t.Logf("writer %s", name)
+			return
+		}
+	}
+
+}
+
+func TestXMLDelete(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+
+	inst := createWriterInstance(&solr.XMLDocumentWriter{})
+
+	err := inst.UpdateDocument(keyset, nil, solr.XMLDelete{IDs: []string{expected[0].ID}, Queries: []string{"tag_value:host2"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(1), countDocuments(t, keyset, metric))
+
+}
@@ -0,0 +1,149 @@
+package solr
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func makeDocsByAge(t *testing.T, metric, keyset string) []DefaultDocument {
+
+	now := time.Now()
+
+	docs := []DefaultDocument{
+		{ID: "old", Metric: metric, Type: "meta", ParentDoc: true, TagKey: "host", TagValue: "host1", CreationDate: solr.FormatDate(now.AddDate(-1, 0, 0))},
+		{ID: "week", Metric: metric, Type: "meta", ParentDoc: true, TagKey: "host", TagValue: "host2", CreationDate: solr.FormatDate(now.AddDate(0, 0, -7))},
+		{ID: "new", Metric: metric, Type: "meta", ParentDoc: true, TagKey: "host", TagValue: "host3", CreationDate: solr.FormatDate(now.Add(-time.Hour))},
+	}
+
+	assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, docs))
+
+	return docs
+}
+
+func docIDs(res *solr.Response) []string {
+
+	docs := res.Docs.([]solr.DocumentRaw)
+
+	ids := make([]string, len(docs))
+	for i := 0; i < len(docs); i++ {
+		ids[i] = docs[i]["id"].(string)
+	}
+
+	return ids
+}
+
+func TestFunctionSortAndPseudoField(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+	makeDocsByAge(t, metric, keyset)
+
+	recency := solr.Recip(solr.MsDiff(solr.Now().Function(), solr.FieldName("creation_date")), 3.16e-11, 1, 1)
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:    "metric:" + metric,
+		FL:   "id," + recency.As("recency"),
+		Sort: recency.Desc(),
+		Rows: 10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"new", "week", "old"}, docIDs(res))
+
+	docs := res.Docs.([]solr.DocumentRaw)
+	assert.True(t, docs[0]["recency"].(float64) > docs[2]["recency"].(float64))
+
+	res, err = defaultInstance.Search(&solr.SearchParams{
+		Q:    "metric:" + metric,
+		Sort: solr.If(solr.Exists(solr.Query("tag_value:host2")), solr.Value(1), solr.Value(0)).Desc() + ", id asc",
+		Rows: 10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"week", "new", "old"}, docIDs(res))
+}
+
+func TestFunctionFilters(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+	makeDocsByAge(t, metric, keyset)
+
+	// the documents created in the last month, in days
+	age := solr.Product(solr.MsDiff(solr.Now().Function(), solr.FieldName("creation_date")), solr.Value(1.0/(24*60*60*1000)))
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:             "metric:" + metric,
+		FilterQueries: []string{age.Frange(math.Inf(-1), 30)},
+		Sort:          "id asc",
+		Rows:          10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"new", "week"}, docIDs(res))
+
+	res, err = defaultInstance.Search(&solr.SearchParams{
+		Q:             "metric:" + metric,
+		FilterQueries: []string{solr.DateRange("creation_date", solr.Now().Round(solr.DateDay).Add(-1, solr.DateMonth), solr.Now())},
+		Sort:          "id asc",
+		Rows:          10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"new", "week"}, docIDs(res))
+}
+
+func TestFunctionBoost(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+	makeDocsByAge(t, metric, keyset)
+
+	recency := solr.Recip(solr.MsDiff(solr.Now().Round(solr.DateHour).Function(), solr.FieldName("creation_date")), 3.16e-11, 1, 1)
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q: metric,
+		EDisMax: &solr.EDisMaxParams{
+			QF:    []string{"metric"},
+			Boost: []string{recency.String()},
+			BF:    []string{solr.Log(solr.Sum(solr.Value(1), solr.Value(1))).String()},
+		},
+		Rows: 10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"new", "week", "old"}, docIDs(res))
+}
+
+func TestDateMath(t *testing.T) {
+
+	assert.Equal(t, "NOW/DAY-1MONTH", solr.Now().Round(solr.DateDay).Add(-1, solr.DateMonth).String())
+	assert.Equal(t, "NOW+2HOUR/HOUR", solr.Now().Add(2, solr.DateHour).Round(solr.DateHour).String())
+	assert.Equal(t, "2020-01-02T03:04:05Z/DAY", solr.Date(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)).Round(solr.DateDay).String())
+	assert.Equal(t, "creation_date:[NOW-7DAY TO NOW]", solr.DateRange("creation_date", solr.Now().Add(-7, solr.DateDay), solr.Now()))
+	assert.Equal(t, "ms(NOW/DAY)", solr.Ms(solr.Now().Round(solr.DateDay).Function()).String())
+	assert.Equal(t, "ms(creation_date)", solr.Ms(solr.FieldName("creation_date")).String())
+	assert.Equal(t, "recip(ms(NOW,creation_date),3.16e-11,1,1)", solr.Recip(solr.MsDiff(solr.Now().Function(), solr.FieldName("creation_date")), 3.16e-11, 1, 1).String())
+	assert.Equal(t, "{!frange l=0 u=2.5}log(field(popularity))", solr.Log(solr.Field("popularity")).Frange(0, 2.5))
+	assert.Equal(t, "query({!v='tag_value:\\'a b\\''})", solr.Query("tag_value:'a b'").String())
+}
@@ -0,0 +1,115 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestRealTimeGet(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	tags := make(map[string]string)
+	tags["host"] = "host"
+
+	expected, err := makeDocs(metric, keyset, "", tags, 2, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := defaultInstance.Get(keyset, expected[0].ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(1), res.NumFound) {
+		return
+	}
+
+	if !assert.Empty(t, res.Missing) {
+		return
+	}
+
+	if !assert.NotZero(t, res.Versions[expected[0].ID]) {
+		return
+	}
+
+	if !testDocumentRaw(t, expected[:1], res.Docs) {
+		return
+	}
+
+	res, err = defaultInstance.GetWithParams(keyset, &solr.GetParams{FL: "id,metric,_version_"}, expected[0].ID, expected[1].ID, "not_found")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(2), res.NumFound) {
+		return
+	}
+
+	if !assert.Equal(t, []string{"not_found"}, res.Missing) {
+		return
+	}
+
+	if !assert.False(t, res.Found("not_found")) {
+		return
+	}
+
+	if !assert.True(t, res.Found(expected[1].ID)) {
+		return
+	}
+
+	res, err = defaultInstance.Get(keyset, "not_found")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(0), res.NumFound) {
+		return
+	}
+
+	assert.Equal(t, []string{"not_found"}, res.Missing)
+
+}
+
+func TestRealTimeGetWithoutIDField(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	tags := make(map[string]string)
+	tags["host"] = "host"
+
+	expected, err := makeDocs(metric, keyset, "", tags, 2, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := defaultInstance.GetWithParams(keyset, &solr.GetParams{FL: "metric"}, expected[0].ID, expected[1].ID, "not_found")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Equal(t, int64(2), res.NumFound) {
+		return
+	}
+
+	if !assert.Equal(t, []string{"not_found"}, res.Missing) {
+		return
+	}
+
+	docs := res.Docs.([]solr.DocumentRaw)
+	if !assert.Len(t, docs, 2) {
+		return
+	}
+
+	assert.Equal(t, metric, docs[0]["metric"])
+	assert.Equal(t, expected[0].ID, docs[0]["id"])
+}
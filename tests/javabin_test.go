@@ -0,0 +1,82 @@
+package solr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestJavabinSearch(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	javabin, err := solr.NewCloud(getSolrAddress(), time.Duration(20*time.Second), time.Duration(20*time.Second), 100, 100, &solr.CloudParams{CollectionConfigName: "mycenae"}, &solr.DefaultDocumentParser{}, &solr.DefaultDocumentWriter{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	javabin.SetResponseFormat(solr.FormatJavabin)
+
+	params := &solr.SearchParams{
+		Q:      "metric:" + metric,
+		Sort:   "id asc",
+		Facets: map[string]string{"facet.field": "tag_value", "facet.missing": "true"},
+		Rows:   10,
+	}
+
+	jsonRes, err := defaultInstance.Search(params, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	javabinRes, err := javabin.Search(params, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, jsonRes.NumFound, javabinRes.NumFound)
+	assert.Equal(t, jsonRes.Docs, javabinRes.Docs)
+	assert.Equal(t, jsonRes.Facets, javabinRes.Facets)
+	assert.Equal(t, jsonRes.Header.Params, javabinRes.Header.Params)
+	assert.True(t, testDocumentRaw(t, expected, javabinRes.Docs))
+}
+
+func TestJavabinTypedSearch(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	if !assert.NoError(t, createChildDocuments(keyset, metric, map[string]string{"host": "host1", "ttl": "1"})) {
+		return
+	}
+
+	javabin := createWriterInstance(&solr.DefaultDocumentWriter{})
+	javabin.SetResponseFormat(solr.FormatJavabin)
+
+	var docs []typedParentDocument
+
+	_, err := javabin.SearchInto(&solr.SearchParams{
+		Q:             "{!parent which=\"parent_doc:true\"}tag_key:host",
+		FL:            "*,[child parentFilter=parent_doc:true]",
+		FilterQueries: []string{"metric:" + metric},
+		Rows:          10,
+	}, keyset, &docs)
+	if !assert.NoError(t, err) || !assert.Len(t, docs, 1) {
+		return
+	}
+
+	assert.Equal(t, metric, docs[0].Metric)
+	assert.Len(t, docs[0].Children, 2)
+}
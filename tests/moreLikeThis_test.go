@@ -0,0 +1,113 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestMoreLikeThis(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3", "host4", "host5"}, false)
+
+	params := &solr.MoreLikeThisParams{
+		ID:     expected[0].ID,
+		Fields: []string{"metric", "tag_key"},
+		MinDF:  1,
+		MinTF:  1,
+		Rows:   10,
+	}
+
+	res, err := defaultInstance.MoreLikeThis(params, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.NotNil(t, res.Match) {
+		return
+	}
+
+	if !assert.Equal(t, int64(1), res.Match.NumFound) {
+		return
+	}
+
+	if !assert.Len(t, res.Similar, 1) {
+		return
+	}
+
+	if !assert.Equal(t, expected[0].ID, res.Similar[0].ID) {
+		return
+	}
+
+	if !assert.Equal(t, int64(len(expected)-1), res.Similar[0].NumFound) {
+		return
+	}
+
+	docs := res.Similar[0].Docs.([]solr.DocumentRaw)
+	for i := 0; i < len(docs); i++ {
+		if !assert.Equal(t, metric, docs[i]["metric"]) {
+			return
+		}
+	}
+
+}
+
+func TestMoreLikeThisHandler(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3", "host4", "host5"}, false)
+
+	params := &solr.MoreLikeThisParams{
+		ID:               expected[0].ID,
+		UseHandler:       true,
+		Fields:           []string{"metric", "tag_key"},
+		MinDF:            1,
+		MinTF:            1,
+		InterestingTerms: "details",
+		Rows:             10,
+	}
+
+	res, err := defaultInstance.MoreLikeThis(params, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.NotNil(t, res.Match) {
+		return
+	}
+
+	if !assert.Len(t, res.Similar, 1) {
+		return
+	}
+
+	if !assert.Equal(t, int64(len(expected)-1), res.Similar[0].NumFound) {
+		return
+	}
+
+	if !assert.NotEmpty(t, res.InterestingTerms) {
+		return
+	}
+
+	terms := map[string]bool{}
+	for _, term := range res.InterestingTerms {
+		terms[term.Term] = true
+	}
+
+	if !assert.True(t, terms["metric:"+metric]) {
+		return
+	}
+
+	_, err = defaultInstance.MoreLikeThis(&solr.MoreLikeThisParams{Stream: metric}, keyset)
+	assert.Error(t, err, "stream queries must require the handler")
+
+}
@@ -0,0 +1,90 @@
+package solr
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestNestedDocumentWriter(t *testing.T) {
+
+	parent := solr.NewNestedDocument(solr.DocumentRaw{"id": "1", "metric": "cpu"})
+	parent.AddChildren(solr.NewNestedDocument(solr.DocumentRaw{"id": "1-1"}))
+	parent.AddLabeledChildren("tags", solr.NewNestedDocument(solr.DocumentRaw{"id": "1-2"}))
+
+	raw, err := (&solr.NestedDocumentWriter{}).Writer(parent)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"id":"1","metric":"cpu","_childDocuments_":[{"id":"1-1"}],"tags":[{"id":"1-2"}]}`, string(raw))
+	}
+
+	raw, err = (&solr.NestedDocumentWriter{Flatten: true}).Writer([]*solr.NestedDocument{parent})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `[{"id":"1","metric":"cpu","_childDocuments_":[{"id":"1-1"},{"id":"1-2"}]}]`, string(raw))
+	}
+
+	assert.Len(t, parent.Descendants(), 2)
+}
+
+func TestNestedDocuments(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	inst, err := solr.NewCloud(getSolrAddress(), time.Duration(20*time.Second), time.Duration(20*time.Second), 100, 100, &solr.CloudParams{CollectionConfigName: "mycenae"}, &solr.NestedDocumentParser{}, &solr.NestedDocumentWriter{Flatten: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	parent := solr.NewNestedDocument(solr.DocumentRaw{
+		"id":         "1",
+		"metric":     metric,
+		"type":       "meta",
+		"parent_doc": true,
+	})
+
+	parent.AddChildren(
+		solr.NewNestedDocument(solr.DocumentRaw{"id": "1-t0", "tag_key": "host", "tag_value": "host1"}),
+		solr.NewNestedDocument(solr.DocumentRaw{"id": "1-t1", "tag_key": "ttl", "tag_value": "1"}),
+	)
+
+	if !assert.NoError(t, inst.UpdateDocument(keyset, nil, []*solr.NestedDocument{parent})) {
+		return
+	}
+
+	res, err := inst.Search(&solr.SearchParams{
+		Q:    "{!parent which=\"parent_doc:true\"}tag_key:host",
+		FL:   "*,[child parentFilter=parent_doc:true]",
+		Rows: 10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	documents, ok := res.Docs.([]*solr.NestedDocument)
+	if !assert.True(t, ok, "expected []*solr.NestedDocument") || !assert.Len(t, documents, 1) {
+		return
+	}
+
+	assert.Equal(t, metric, documents[0].Fields["metric"])
+
+	if !assert.Len(t, documents[0].Children, 2) {
+		return
+	}
+
+	tags := map[string]interface{}{}
+	for _, child := range documents[0].Children {
+		tags[child.Fields["tag_key"].(string)] = child.Fields["tag_value"]
+	}
+
+	assert.Equal(t, map[string]interface{}{"host": "host1", "ttl": "1"}, tags)
+
+	raw, err := json.Marshal(documents[0])
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(raw), "_childDocuments_")
+	}
+}
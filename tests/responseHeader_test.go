@@ -0,0 +1,75 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestResponseHeader(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:             "metric:" + metric,
+		FL:            "*,score",
+		FilterQueries: []string{"type:meta", "tag_key:host"},
+		Start:         1,
+		Rows:          10,
+	}, keyset)
+	if !assert.NoError(t, err) || !assert.NotNil(t, res.Header) {
+		return
+	}
+
+	assert.Equal(t, int64(1), res.Header.Start)
+	assert.True(t, res.Header.MaxScore > 0)
+	assert.True(t, res.Header.ZkConnected)
+	assert.False(t, res.Header.PartialResults)
+	assert.Equal(t, []string{"metric:" + metric}, res.Header.Params["q"])
+	assert.Equal(t, []string{"type:meta", "tag_key:host"}, res.Header.Params["fq"])
+}
+
+func TestCursorMark(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	params := &solr.SearchParams{Q: "metric:" + metric, Sort: "id asc", Rows: 2, CursorMark: "*"}
+	var ids []string
+
+	for i := 0; i < len(expected); i++ {
+
+		res, err := defaultInstance.Search(params, keyset)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, doc := range res.Docs.([]solr.DocumentRaw) {
+			ids = append(ids, doc["id"].(string))
+		}
+
+		if res.Header.NextCursorMark == params.CursorMark {
+			break
+		}
+
+		params.CursorMark = res.Header.NextCursorMark
+	}
+
+	assert.Equal(t, []string{"0", "1", "2"}, ids)
+}
@@ -0,0 +1,90 @@
+package solr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+type typedDocument struct {
+	ID           string    `solr:"id"`
+	Metric       string    `solr:"metric"`
+	Type         string    `solr:"type"`
+	TagKey       string    `solr:"tag_key"`
+	TagValue     string    `solr:"tag_value"`
+	CreationDate time.Time `solr:"creation_date"`
+}
+
+type typedParentDocument struct {
+	ID       string          `solr:"id"`
+	Metric   string          `solr:"metric"`
+	Children []typedDocument `solr:"_childDocuments_"`
+}
+
+func TestSearchInto(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	creation := time.Now().UTC().Truncate(time.Second)
+	expected := makeDocsByArray(metric, keyset, creation.Format("2006-01-02T15:04:05Z"), "host", []string{"host1", "host2", "host3"}, false)
+
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	var docs []typedDocument
+
+	res, err := defaultInstance.SearchInto(&solr.SearchParams{Q: "metric:" + metric, Sort: "id asc", Rows: 10}, keyset, &docs)
+	if !assert.NoError(t, err) || !assert.Len(t, docs, len(expected)) {
+		return
+	}
+
+	assert.Equal(t, int64(len(expected)), res.NumFound)
+	assert.Equal(t, docs, res.Docs)
+
+	for i := 0; i < len(expected); i++ {
+		assert.Equal(t, expected[i].ID, docs[i].ID)
+		assert.Equal(t, expected[i].TagValue, docs[i].TagValue)
+		assert.True(t, creation.Equal(docs[i].CreationDate), "expected %s, got %s", creation, docs[i].CreationDate)
+	}
+}
+
+func TestSearchIntoChildDocuments(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	if !assert.NoError(t, createChildDocuments(keyset, metric, map[string]string{"host": "host1", "ttl": "1"})) {
+		return
+	}
+
+	var docs []*typedParentDocument
+
+	_, err := defaultInstance.SearchInto(&solr.SearchParams{
+		Q:             "{!parent which=\"parent_doc:true\"}tag_key:host",
+		FL:            "*,[child parentFilter=parent_doc:true]",
+		FilterQueries: []string{"metric:" + metric},
+		Rows:          10,
+	}, keyset, &docs)
+	if !assert.NoError(t, err) || !assert.Len(t, docs, 1) {
+		return
+	}
+
+	assert.Equal(t, metric, docs[0].Metric)
+	assert.Len(t, docs[0].Children, 2)
+}
+
+func TestSearchIntoInvalidDestination(t *testing.T) {
+
+	var docs []string
+
+	_, err := defaultInstance.SearchInto(&solr.SearchParams{Q: "*:*"}, randomKeyset(), &docs)
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package solr
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestSearchGenericParams(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:              metric + " host1",
+		RequestHandler: "/query",
+		DF:             "metric",
+		QOp:            "OR",
+		DefType:        "edismax",
+		TimeAllowed:    10 * time.Second,
+		Shards:         []string{keyset},
+		Params: url.Values{
+			"qf":             []string{"metric tag_value"},
+			"fq":             []string{"type:meta", "tag_key:host"},
+			"facet":          []string{"true"},
+			"facet.field":    []string{"tag_value", "tag_key"},
+			"facet.mincount": []string{"1"},
+			"echoParams":     []string{"all"},
+		},
+		Sort: "id asc",
+		Rows: 10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(3), res.NumFound)
+	assert.True(t, testDocumentRaw(t, expected, res.Docs))
+
+	if assert.Len(t, res.Facets, 2) {
+		assert.Equal(t, "tag_value", res.Facets[0].Name)
+		assert.Len(t, res.Facets[0].List, 3)
+		assert.Equal(t, "tag_key", res.Facets[1].Name)
+		assert.Len(t, res.Facets[1].List, 1)
+	}
+
+	assert.Equal(t, []string{"metric"}, res.Header.Params["df"])
+	assert.Equal(t, []string{"OR"}, res.Header.Params["q.op"])
+	assert.Equal(t, []string{"edismax"}, res.Header.Params["defType"])
+	assert.Equal(t, []string{"10000"}, res.Header.Params["timeAllowed"])
+	assert.Equal(t, []string{"type:meta", "tag_key:host"}, res.Header.Params["fq"])
+
+	_, err = defaultInstance.Search(&solr.SearchParams{Q: "*:*", RequestHandler: "/notfound"}, keyset)
+	assert.Error(t, err)
+}
+
+func TestSearchSegmentTerminateEarly(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	res, err := defaultInstance.Search(&solr.SearchParams{
+		Q:                     "metric:" + metric,
+		SegmentTerminateEarly: true,
+		Params:                url.Values{"echoParams": []string{"explicit"}},
+		Rows:                  10,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(3), res.NumFound)
+	assert.Equal(t, []string{"true"}, res.Header.Params["segmentTerminateEarly"])
+}
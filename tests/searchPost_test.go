@@ -0,0 +1,138 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestSearchPostLongQuery(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	// a filter longer than the jetty request line limit
+	values := make([]string, 1000)
+	for i := 0; i < len(values); i++ {
+		values[i] = fmt.Sprintf("host%d", i)
+	}
+
+	params := &solr.SearchParams{
+		Q:             "metric:" + metric,
+		FilterQueries: []string{"tag_value:(" + strings.Join(values, " OR ") + ")"},
+		Rows:          10,
+	}
+
+	res, err := defaultInstance.Search(params, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(3), res.NumFound)
+	assert.True(t, testDocumentRaw(t, expected, res.Docs))
+
+	post, err := solr.NewCloud(getSolrAddress(), time.Duration(20*time.Second), time.Duration(20*time.Second), 100, 100, &solr.CloudParams{CollectionConfigName: "mycenae"}, &solr.DefaultDocumentParser{}, &solr.DefaultDocumentWriter{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	post.SetSearchMethod(solr.SearchPost, 0)
+
+	res, err = post.Search(&solr.SearchParams{Q: "metric:" + metric, Rows: 10}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(3), res.NumFound)
+
+	post.SetSearchMethod(solr.SearchGet, 0)
+
+	_, err = post.Search(params, keyset)
+	assert.Error(t, err)
+}
+
+func TestSearchJSON(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	res, err := defaultInstance.SearchJSON(&solr.JSONRequest{
+		Query:  "metric:" + metric,
+		Filter: []string{"tag_key:host"},
+		Sort:   "id asc",
+		Limit:  10,
+		Facet: map[string]interface{}{
+			"tags": map[string]interface{}{"type": "terms", "field": "tag_value"},
+		},
+		Params: map[string]interface{}{"facet": true, "facet.field": "tag_value"},
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(3), res.NumFound)
+	assert.True(t, testDocumentRaw(t, expected, res.Docs))
+
+	if assert.Len(t, res.Facets, 1) {
+		assert.Equal(t, "tag_value", res.Facets[0].Name)
+		assert.Len(t, res.Facets[0].List, 3)
+	}
+
+	if assert.NotNil(t, res.JSONFacets) {
+		assert.Equal(t, float64(3), res.JSONFacets["count"])
+
+		tags, ok := res.JSONFacets["tags"].(map[string]interface{})
+		if assert.True(t, ok) {
+			assert.Len(t, tags["buckets"], 3)
+		}
+	}
+
+	_, err = defaultInstance.SearchJSON(nil, keyset)
+	assert.Error(t, err)
+}
+
+func TestSearchJSONDefaultLimit(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	tags := make([]string, 12)
+	for i := 0; i < len(tags); i++ {
+		tags[i] = fmt.Sprintf("host%d", i)
+	}
+
+	expected := makeDocsByArray(metric, keyset, "", "host", tags, false)
+	if !assert.NoError(t, defaultInstance.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	res, err := defaultInstance.SearchJSON(&solr.JSONRequest{
+		Query: "metric:" + metric,
+	}, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(12), res.NumFound)
+	assert.Len(t, res.Docs.([]solr.DocumentRaw), 10)
+}
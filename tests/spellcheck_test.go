@@ -0,0 +1,89 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestSpellcheck(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := "cpu_usage_percent"
+
+	createCollection(t, keyset)
+
+	tags := make(map[string]string)
+	tags["host"] = "host"
+
+	_, err := makeDocs(metric, keyset, "", tags, 10, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	searchParams := &solr.SearchParams{
+		Q:    "metric:cpu_usage_percant",
+		Rows: 10,
+		Spellcheck: &solr.SpellcheckParams{
+			Q:                      "cpu_usage_percant",
+			Count:                  5,
+			Collate:                true,
+			MaxCollationTries:      5,
+			ExtendedResults:        true,
+			CollateExtendedResults: true,
+		},
+	}
+
+	res, err := defaultInstance.Search(searchParams, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.NotNil(t, res.Spellcheck) {
+		return
+	}
+
+	if !assert.False(t, res.Spellcheck.CorrectlySpelled) {
+		return
+	}
+
+	if !assert.Len(t, res.Spellcheck.Suggestions, 1) {
+		return
+	}
+
+	suggestion := res.Spellcheck.Suggestions[0]
+
+	if !assert.Equal(t, "cpu_usage_percant", suggestion.Token) {
+		return
+	}
+
+	if !assert.NotEmpty(t, suggestion.Words) {
+		return
+	}
+
+	if !assert.Equal(t, metric, suggestion.Words[0].Word) {
+		return
+	}
+
+	if !assert.Equal(t, int64(10), suggestion.Words[0].Freq) {
+		return
+	}
+
+	if !assert.NotEmpty(t, res.Spellcheck.Collations) {
+		return
+	}
+
+	collation := res.Spellcheck.Collations[0]
+
+	if !assert.Equal(t, int64(10), collation.Hits) {
+		return
+	}
+
+	if !assert.Len(t, collation.Corrections, 1) {
+		return
+	}
+
+	assert.Equal(t, metric, collation.Corrections[0].Correction)
+
+}
@@ -0,0 +1,94 @@
+package solr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestStats(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	oneHour := now.Add(-1 * time.Hour)
+
+	tags := make(map[string]string)
+	tags["host"] = "host"
+
+	_, err := makeDocs(metric, keyset, oneHour.Format("2006-01-02T15:04:05Z"), tags, 5, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tags2 := make(map[string]string)
+	tags2["service"] = "solr"
+
+	_, err = makeDocs(metric, keyset, now.Format("2006-01-02T15:04:05Z"), tags2, 3, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	searchParams := &solr.SearchParams{
+		Q:    "metric:" + metric,
+		Rows: 0,
+		Stats: &solr.StatsParams{
+			Fields: []solr.StatsFieldParams{
+				{Field: "creation_date"},
+				{Field: "tag_value", Key: "distinct_values", CountDistinct: true},
+			},
+			Facets: []string{"tag_key"},
+		},
+	}
+
+	res, err := defaultInstance.Search(searchParams, keyset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	creationDate, ok := res.Stats["creation_date"]
+	if !assert.True(t, ok, "expected creation_date stats") {
+		return
+	}
+
+	if !assert.Equal(t, oneHour, creationDate.Min) {
+		return
+	}
+
+	if !assert.Equal(t, now, creationDate.Max) {
+		return
+	}
+
+	if !assert.Equal(t, int64(8), creationDate.Count) {
+		return
+	}
+
+	distinct, ok := res.Stats["distinct_values"]
+	if !assert.True(t, ok, "expected distinct_values stats") {
+		return
+	}
+
+	if !assert.Equal(t, int64(8), distinct.CountDistinct) {
+		return
+	}
+
+	if !assert.Len(t, distinct.DistinctValues, 8) {
+		return
+	}
+
+	if !assert.Contains(t, distinct.Facets, "tag_key") {
+		return
+	}
+
+	if !assert.Equal(t, int64(5), distinct.Facets["tag_key"]["host"].CountDistinct) {
+		return
+	}
+
+	assert.Equal(t, int64(3), distinct.Facets["tag_key"]["service"].CountDistinct)
+
+}
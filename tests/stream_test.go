@@ -0,0 +1,37 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestUpdateDocumentStream(t *testing.T) {
+
+	writers := map[string]solr.DocumentWriter{
+		"default":    &solr.DefaultDocumentWriter{},
+		"json_lines": &solr.JSONLinesDocumentWriter{},
+		"xml":        &solr.XMLDocumentWriter{},
+	}
+
+	for name, writer := range writers {
+
+		keyset := randomKeyset()
+		metric := randomMetric()
+
+		createCollection(t, keyset)
+
+		inst := createWriterInstance(writer)
+
+		expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+
+		err := inst.UpdateDocumentStream(keyset, nil, expected, nil)
+		if !assert.NoError(t, err, "writer %s", name) {
+			return
+		}
+
+		assert.Equal(t, int64(len(expected)), countDocuments(t, keyset, metric), "writer %s", name)
+	}
+
+}
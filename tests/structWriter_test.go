@@ -0,0 +1,101 @@
+package solr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+type structDocument struct {
+	ID       string      `solr:"id"`
+	Metric   string      `solr:"metric"`
+	Type     string      `solr:"type"`
+	Parent   bool        `solr:"parent_doc"`
+	Created  time.Time   `solr:"creation_date"`
+	Owner    string      `solr:"owner,dynamic,omitempty"`
+	Tags     []string    `solr:"tags,dynamic"`
+	Points   int64       `solr:"points,dynamic"`
+	Expires  time.Time   `solr:"expires,dynamic,omitempty"`
+	Children []structTag `solr:"_childDocuments_"`
+}
+
+type structTag struct {
+	ID       string `solr:"id"`
+	TagKey   string `solr:"tag_key"`
+	TagValue string `solr:"tag_value"`
+}
+
+func TestStructDocumentWriter(t *testing.T) {
+
+	raw, err := (&solr.StructDocumentWriter{}).Writer(structDocument{
+		ID:      "1",
+		Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("BRT", -3*3600)),
+		Tags:    []string{"a", "b"},
+	})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"id":"1","metric":"","type":"","parent_doc":false,"creation_date":"2020-01-02T06:04:05Z","tags_ss":["a","b"],"points_l":0}`, string(raw))
+		assert.NotContains(t, string(raw), "_childDocuments_")
+		assert.NotContains(t, string(raw), "null")
+	}
+
+	raw, err = (&solr.StructDocumentWriter{}).Writer(structDocument{
+		ID:       "2",
+		Children: []structTag{{ID: "2-1", TagKey: "host"}},
+	})
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(raw), `"_childDocuments_":[{"id":"2-1","tag_key":"host","tag_value":""}]`)
+		assert.NotContains(t, string(raw), "tags_ss")
+	}
+}
+
+func TestStructDocumentWriterRoundTrip(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	inst := createWriterInstance(&solr.StructDocumentWriter{})
+
+	created := time.Now().UTC().Truncate(time.Millisecond)
+
+	expected := []structDocument{{
+		ID:      "1",
+		Metric:  metric,
+		Type:    "meta",
+		Parent:  true,
+		Created: created,
+		Owner:   "team",
+		Tags:    []string{"a", "b"},
+		Points:  42,
+		Children: []structTag{
+			{ID: "1-t0", TagKey: "host", TagValue: "host1"},
+		},
+	}}
+
+	if !assert.NoError(t, inst.UpdateDocument(keyset, nil, expected)) {
+		return
+	}
+
+	var docs []structDocument
+
+	_, err := inst.SearchInto(&solr.SearchParams{
+		Q:             "{!parent which=\"parent_doc:true\"}tag_key:host",
+		FL:            "*,[child parentFilter=parent_doc:true]",
+		FilterQueries: []string{"metric:" + metric},
+		Rows:          10,
+	}, keyset, &docs)
+	if !assert.NoError(t, err) || !assert.Len(t, docs, 1) {
+		return
+	}
+
+	// parent_doc is not stored
+	docs[0].Parent = true
+
+	assert.True(t, created.Equal(docs[0].Created))
+	docs[0].Created = created
+
+	assert.Equal(t, expected, docs)
+}
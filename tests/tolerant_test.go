@@ -0,0 +1,62 @@
+package solr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestUpdateDocumentTolerant(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	docs := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	docs[1].CreationDate = "not a date"
+
+	err := defaultInstance.UpdateDocumentTolerant(keyset, nil, docs, nil)
+
+	var partial *solr.PartialFailureError
+	if !assert.True(t, errors.As(err, &partial), "expected a partial failure: %v", err) {
+		return
+	}
+
+	assert.False(t, partial.Aborted)
+	assert.Equal(t, []string{docs[1].ID}, partial.IDs())
+
+	documents := make([]interface{}, len(docs))
+	for i := 0; i < len(docs); i++ {
+		documents[i] = docs[i]
+	}
+
+	rejected, err := partial.Rejected(documents)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []interface{}{docs[1]}, rejected)
+	}
+
+	assert.Equal(t, int64(2), countDocuments(t, keyset, metric))
+}
+
+func TestUpdateDocumentTolerantMaxErrors(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	docs := makeDocsByArray(metric, keyset, "", "host", []string{"host1", "host2", "host3"}, false)
+	docs[0].CreationDate = "not a date"
+	docs[1].CreationDate = "not a date"
+
+	err := defaultInstance.UpdateDocumentTolerant(keyset, nil, docs, &solr.TolerantOptions{MaxErrors: 1})
+
+	var partial *solr.PartialFailureError
+	if assert.True(t, errors.As(err, &partial), "expected a partial failure: %v", err) {
+		assert.True(t, partial.Aborted)
+		assert.Equal(t, 1, partial.MaxErrors)
+	}
+}
@@ -0,0 +1,117 @@
+package solr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func TestVersionConflict(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1"}, false)
+
+	res, err := defaultInstance.Get(keyset, expected[0].ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	version := res.Versions[expected[0].ID]
+
+	err = defaultInstance.UpdateAtomic(keyset, nil, solr.NewAtomicUpdate(expected[0].ID).ExpectVersion(version+1).Set("tag_value", "host2"))
+
+	var conflict *solr.VersionConflictError
+	if !assert.True(t, errors.As(err, &conflict), "expected a version conflict error: %v", err) {
+		return
+	}
+
+	if !assert.Equal(t, 409, conflict.Code) {
+		return
+	}
+
+	err = defaultInstance.UpdateAtomic(keyset, nil, solr.NewAtomicUpdate(expected[0].ID).ExpectVersion(solr.VersionMustNotExist).Set("tag_value", "host2"))
+	if !assert.True(t, errors.As(err, &conflict), "expected a version conflict error: %v", err) {
+		return
+	}
+
+	err = defaultInstance.UpdateAtomic(keyset, nil, solr.NewAtomicUpdate(expected[0].ID).ExpectVersion(version).Set("tag_value", "host2"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = defaultInstance.UpdateAtomic(keyset, nil, solr.NewAtomicUpdate("not_found").ExpectVersion(solr.VersionMustExist).Set("tag_value", "host2"))
+	assert.True(t, errors.As(err, &conflict), "expected a version conflict error: %v", err)
+
+}
+
+func TestReadModifyWrite(t *testing.T) {
+
+	keyset := randomKeyset()
+	metric := randomMetric()
+
+	createCollection(t, keyset)
+
+	expected := makeDocsByArray(metric, keyset, "", "host", []string{"host1"}, false)
+
+	writers := 5
+
+	wg := sync.WaitGroup{}
+	wg.Add(writers)
+
+	for i := 0; i < writers; i++ {
+
+		go func(i int) {
+
+			defer wg.Done()
+
+			err := defaultInstance.ReadModifyWrite(keyset, expected[0].ID, 10, func(document solr.DocumentRaw) (solr.DocumentRaw, error) {
+
+				if document == nil {
+					return nil, fmt.Errorf("document %s not found", expected[0].ID)
+				}
+
+				document["tag_value"] = fmt.Sprintf("%v,%d", document["tag_value"], i)
+
+				return document, nil
+			})
+
+			assert.NoError(t, err)
+
+		}(i)
+	}
+
+	wg.Wait()
+
+	res, err := defaultInstance.Get(keyset, expected[0].ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tagValue := res.Docs.([]solr.DocumentRaw)[0]["tag_value"].(string)
+
+	for i := 0; i < writers; i++ {
+		if !assert.Contains(t, tagValue, fmt.Sprintf(",%d", i), "no update can be lost") {
+			return
+		}
+	}
+
+	err = defaultInstance.ReadModifyWrite(keyset, "new_document", 0, func(document solr.DocumentRaw) (solr.DocumentRaw, error) {
+
+		if !assert.Nil(t, document) {
+			return nil, nil
+		}
+
+		return solr.DocumentRaw{"metric": metric, "tag_key": "host", "tag_value": "host2"}, nil
+	})
+
+	assert.NoError(t, err)
+
+}
@@ -0,0 +1,103 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/uol/solr"
+)
+
+func makeResponseBody(docSize int) []byte {
+
+	body := strings.Builder{}
+	body.WriteString(`{"responseHeader":{"status":0,"QTime":1},"response":{"numFound":`)
+	body.WriteString(fmt.Sprintf("%d", docSize))
+	body.WriteString(`,"start":0,"docs":[`)
+
+	for i := 0; i < docSize; i++ {
+
+		if i > 0 {
+			body.WriteString(",")
+		}
+
+		body.WriteString(fmt.Sprintf(`{"id":"%d","metric":"metric%d","type":"meta","tag_key":"host","tag_value":"host%d","creation_date":"2020-01-02T03:04:05Z","_version_":%d}`, i, i, i, 1600000000000000000+i))
+	}
+
+	body.WriteString(`]}}`)
+
+	return []byte(body.String())
+}
+
+func TestVisitorDocumentParser(t *testing.T) {
+
+	var metrics []string
+
+	parser := &solr.VisitorDocumentParser{
+		Visitor: func(document int, name []byte, dataType jsonparser.ValueType, value []byte) error {
+
+			if string(name) == "metric" {
+				assert.Equal(t, jsonparser.String, dataType)
+				metrics = append(metrics, fmt.Sprintf("%d:%s", document, value))
+			}
+
+			return nil
+		},
+	}
+
+	count, err := parser.Parse(makeResponseBody(3))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, count)
+		assert.Equal(t, []string{"0:metric0", "1:metric1", "2:metric2"}, metrics)
+	}
+
+	failing := &solr.VisitorDocumentParser{
+		Visitor: func(document int, name []byte, dataType jsonparser.ValueType, value []byte) error {
+			return fmt.Errorf("stop")
+		},
+	}
+
+	_, err = failing.Parse(makeResponseBody(3))
+	assert.EqualError(t, err, "stop")
+}
+
+func BenchmarkDefaultDocumentParser(b *testing.B) {
+
+	body := makeResponseBody(1000)
+	parser := &solr.DefaultDocumentParser{}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.Parse(body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVisitorDocumentParser(b *testing.B) {
+
+	body := makeResponseBody(1000)
+
+	var fields int
+	parser := &solr.VisitorDocumentParser{
+		Visitor: func(document int, name []byte, dataType jsonparser.ValueType, value []byte) error {
+			fields++
+			return nil
+		},
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.Parse(body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package solr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxErrorsUnlimited - the TolerantOptions.MaxErrors value accepting any number of rejected documents
+const MaxErrorsUnlimited int = -1
+
+// TolerantOptions - options of UpdateDocumentTolerant, the collection config must define an update chain with
+// the TolerantUpdateProcessorFactory, for more information visit https://lucene.apache.org/solr/guide/7_4/update-request-processors.html#tolerantupdateprocessorfactory
+type TolerantOptions struct {
+	Chain     string         //Chain - the update chain name, default "tolerant"
+	MaxErrors int            //MaxErrors - the maximum number of rejected documents, zero uses the maxErrors of the chain (unlimited by default) and MaxErrorsUnlimited disables the limit
+	FailFast  bool           //FailFast - fails the update on the first rejected document (maxErrors=0), cannot be used with MaxErrors
+	Commit    *CommitOptions //Commit - commit options of the update, nil uses the Instance commit options
+}
+
+//DocumentError - an update command rejected by the TolerantUpdateProcessor
+type DocumentError struct {
+	Type    string //Type - the command type: ADD, DELID or DELQ
+	ID      string //ID - the document id, or the query of a DELQ command
+	Message string
+}
+
+//PartialFailureError - some update commands were rejected, the others were indexed unless Aborted is true,
+// when the maxErrors limit was exceeded and the update failed
+type PartialFailureError struct {
+	SolrError
+	Errors    []DocumentError
+	MaxErrors int
+	Aborted   bool
+}
+
+// Error - the error message with the rejected ids
+func (e *PartialFailureError) Error() string {
+
+	out := strings.Builder{}
+
+	if e.Aborted {
+		fmt.Fprintf(&out, "update aborted, solr rejected %d documents (maxErrors %d)", len(e.Errors), e.MaxErrors)
+	} else {
+		fmt.Fprintf(&out, "solr rejected %d documents", len(e.Errors))
+	}
+
+	for i := 0; i < len(e.Errors); i++ {
+		fmt.Fprintf(&out, "; %s %s: %s", e.Errors[i].Type, e.Errors[i].ID, e.Errors[i].Message)
+	}
+
+	return out.String()
+
+}
+
+// IDs - the ids of the rejected documents
+func (e *PartialFailureError) IDs() []string {
+
+	ids := make([]string, 0, len(e.Errors))
+	for i := 0; i < len(e.Errors); i++ {
+		if e.Errors[i].Type != updateDeleteByQuery {
+			ids = append(ids, e.Errors[i].ID)
+		}
+	}
+
+	return ids
+
+}
+
+// Rejected - filters the documents rejected by solr, the documents are serialized with encoding/json to read their id
+func (e *PartialFailureError) Rejected(documents []interface{}) ([]interface{}, error) {
+
+	rejected := map[string]bool{}
+	for i := 0; i < len(e.Errors); i++ {
+		if e.Errors[i].Type == updateAdd {
+			rejected[e.Errors[i].ID] = true
+		}
+	}
+
+	var result []interface{}
+
+	for i := 0; i < len(documents); i++ {
+
+		id, err := documentID(documents[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if rejected[id] {
+			result = append(result, documents[i])
+		}
+	}
+
+	return result, nil
+
+}
+
+// documentID - the id field of a document, serialized with encoding/json
+func documentID(document interface{}) (string, error) {
+
+	decoded, err := decodeDocumentJSON(document)
+	if err != nil {
+		return "", err
+	}
+
+	fields, ok := decoded.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("payload is not a single document")
+	}
+
+	return fmt.Sprint(fields[rawID]), nil
+
+}
+
+func newPartialFailureError(solrError SolrError, response *responseRaw) *PartialFailureError {
+
+	partial := &PartialFailureError{
+		SolrError: solrError,
+		Errors:    make([]DocumentError, len(response.Header.Errors)),
+		MaxErrors: response.Header.MaxErrors,
+		Aborted:   solrError.Code != 0,
+	}
+
+	for i, e := range response.Header.Errors {
+		partial.Errors[i] = DocumentError{Type: e.Type, ID: e.ID, Message: e.Message}
+	}
+
+	return partial
+
+}
+
+// UpdateDocumentTolerant - same as UpdateDocument but the invalid documents do not fail the whole update,
+// a *PartialFailureError is returned with the rejected documents, nil options accept any number of errors
+func (s *Instance) UpdateDocumentTolerant(instanceName string, postParams map[string]string, payload interface{}, options *TolerantOptions) error {
+
+	if options == nil {
+		options = &TolerantOptions{MaxErrors: MaxErrorsUnlimited}
+	}
+
+	if options.FailFast && options.MaxErrors != 0 {
+		return fmt.Errorf("FailFast cannot be used with MaxErrors")
+	}
+
+	chain := options.Chain
+	if chain == "" {
+		chain = tolerantChain
+	}
+
+	params := make(map[string]string, len(postParams)+2)
+	for k, v := range postParams {
+		params[k] = v
+	}
+
+	params[stringUpdateChain] = chain
+
+	if options.FailFast {
+		params[stringMaxErrors] = strconv.Itoa(0)
+	} else if options.MaxErrors != 0 {
+		params[stringMaxErrors] = strconv.Itoa(options.MaxErrors)
+	}
+
+	return s.UpdateDocumentWithOptions(instanceName, params, payload, options.Commit)
+
+}
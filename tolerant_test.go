@@ -0,0 +1,43 @@
+package solr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDocumentTolerantMaxErrorsParam(t *testing.T) {
+
+	var maxErrors []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxErrors = r.URL.Query()["maxErrors"]
+		w.Write([]byte(`{"responseHeader":{"status":0,"QTime":1}}`))
+	}))
+	defer server.Close()
+
+	s := &Instance{coreURL: server.URL, httpPostClient: server.Client(), documentWriter: &DefaultDocumentWriter{}}
+	documents := []map[string]interface{}{{"id": "1"}}
+
+	cases := []struct {
+		options  *TolerantOptions
+		expected []string
+	}{
+		{nil, []string{"-1"}},
+		{&TolerantOptions{}, nil},
+		{&TolerantOptions{MaxErrors: 10}, []string{"10"}},
+		{&TolerantOptions{MaxErrors: MaxErrorsUnlimited}, []string{"-1"}},
+		{&TolerantOptions{FailFast: true}, []string{"0"}},
+	}
+
+	for i, c := range cases {
+		if assert.NoError(t, s.UpdateDocumentTolerant("collection", nil, documents, c.options), i) {
+			assert.Equal(t, c.expected, maxErrors, i)
+		}
+	}
+
+	err := s.UpdateDocumentTolerant("collection", nil, documents, &TolerantOptions{FailFast: true, MaxErrors: 1})
+	assert.EqualError(t, err, "FailFast cannot be used with MaxErrors")
+}
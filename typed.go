@@ -0,0 +1,492 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+)
+
+// The `solr` struct tag maps a struct field to a solr field, ex: `solr:"tag_key"`, `solr:"-"` ignores the field
+// and fields without the tag use their own name. Multivalued fields map to slices, pdate fields to time.Time and
+// child documents (_childDocuments_ or labeled) to structs or slices of structs.
+//
+// The options after the name are:
+//   omitempty - does not write the zero values
+//   dynamic - appends the dynamic field suffix of the Go type to the name, ex: _s, _ss, _l, _dt
+//   dynamic=_txt - appends the given suffix to the name
+const solrTag string = "solr"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structField - a struct field mapped to a solr field
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structInfo - the mapped fields of a struct type
+type structInfo struct {
+	fields []*structField
+	byName map[string]*structField
+}
+
+var structInfoCache sync.Map
+
+// getStructInfo - returns the cached mapping of the struct type
+func getStructInfo(t reflect.Type) *structInfo {
+
+	if info, ok := structInfoCache.Load(t); ok {
+		return info.(*structInfo)
+	}
+
+	info := &structInfo{byName: map[string]*structField{}}
+	info.addFields(t, nil)
+
+	cached, _ := structInfoCache.LoadOrStore(t, info)
+
+	return cached.(*structInfo)
+
+}
+
+func (info *structInfo) addFields(t reflect.Type, parent []int) {
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup(solrTag)
+
+		if tag == "-" {
+			continue
+		}
+
+		index := make([]int, len(parent)+1)
+		copy(index, parent)
+		index[len(parent)] = i
+
+		// the fields of embedded structs are promoted like encoding/json does
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			info.addFields(field.Type, index)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		options := strings.Split(tag, stringComma)
+
+		mapped := &structField{
+			name:  options[0],
+			index: index,
+		}
+
+		if mapped.name == "" {
+			mapped.name = field.Name
+		}
+
+		for _, option := range options[1:] {
+
+			switch {
+			case option == tagOmitEmpty:
+				mapped.omitEmpty = true
+			case option == tagDynamic:
+				mapped.name += dynamicSuffix(field.Type)
+			case strings.HasPrefix(option, tagDynamic+stringEqual):
+				mapped.name += strings.TrimPrefix(option, tagDynamic+stringEqual)
+			}
+		}
+
+		if _, exists := info.byName[mapped.name]; exists {
+			continue
+		}
+
+		info.fields = append(info.fields, mapped)
+		info.byName[mapped.name] = mapped
+	}
+
+}
+
+// dynamicSuffix - the suffix of the default dynamic fields for the type, multivalued for slices
+func dynamicSuffix(t reflect.Type) string {
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var multiValued string
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		multiValued = "s"
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+
+	if t == timeType {
+		return "_dt" + multiValued
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "_b" + multiValued
+	case reflect.Int, reflect.Int64, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		return "_l" + multiValued
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "_i" + multiValued
+	case reflect.Float32:
+		return "_f" + multiValued
+	case reflect.Float64:
+		return "_d" + multiValued
+	default:
+		return "_s" + multiValued
+	}
+
+}
+
+// UnmarshalDocuments - decodes a JSON array of solr documents into dst, a pointer to a slice of structs or struct pointers
+func UnmarshalDocuments(documents []byte, dst interface{}) error {
+
+	slice, err := documentSlice(dst)
+	if err != nil {
+		return err
+	}
+
+	elemType := slice.Type().Elem()
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	var decodeError error
+
+	_, err = jsonparser.ArrayEach(documents, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+		if decodeError != nil {
+			return
+		}
+
+		document := reflect.New(elemType).Elem()
+
+		if decodeError = decodeValue(value, dataType, document); decodeError == nil {
+			result = reflect.Append(result, document)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if decodeError != nil {
+		return decodeError
+	}
+
+	slice.Set(result)
+
+	return nil
+
+}
+
+// documentSlice - validates dst and returns the slice it points to
+func documentSlice(dst interface{}) (reflect.Value, error) {
+
+	value := reflect.ValueOf(dst)
+
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("dst must be a pointer to a slice, got %T", dst)
+	}
+
+	elemType := value.Elem().Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("dst must be a pointer to a slice of structs, got %T", dst)
+	}
+
+	return value.Elem(), nil
+
+}
+
+// decodeDocument - decodes a solr document into a struct
+func decodeDocument(raw []byte, v reflect.Value) error {
+
+	info := getStructInfo(v.Type())
+
+	return jsonparser.ObjectEach(raw, func(key, value []byte, dataType jsonparser.ValueType, offset int) error {
+
+		field, ok := info.byName[string(key)]
+		if !ok {
+			return nil
+		}
+
+		if err := decodeValue(value, dataType, v.FieldByIndex(field.index)); err != nil {
+			return fmt.Errorf("field %s: %v", field.name, err)
+		}
+
+		return nil
+	})
+
+}
+
+// decodeValue - decodes a JSON value into v, a single value becomes a slice of one element
+// and the first value of an array is used when v is not a slice
+func decodeValue(raw []byte, dataType jsonparser.ValueType, v reflect.Value) error {
+
+	if dataType == jsonparser.Null {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+
+		ptr := reflect.New(v.Type().Elem())
+		if err := decodeValue(raw, dataType, ptr.Elem()); err != nil {
+			return err
+		}
+
+		v.Set(ptr)
+		return nil
+
+	case reflect.Interface:
+
+		if v.NumMethod() > 0 {
+			return fmt.Errorf("cannot decode into %s", v.Type())
+		}
+
+		var value interface{}
+		var err error
+
+		if dataType == jsonparser.String {
+			value, err = jsonparser.ParseString(raw)
+		} else {
+			err = json.Unmarshal(raw, &value)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(value))
+		return nil
+
+	case reflect.Slice:
+
+		return decodeSlice(raw, dataType, v)
+
+	case reflect.Map:
+
+		if dataType != jsonparser.Object {
+			return fmt.Errorf("cannot decode %s into %s", dataType, v.Type())
+		}
+
+		ptr := reflect.New(v.Type())
+		if err := json.Unmarshal(raw, ptr.Interface()); err != nil {
+			return err
+		}
+
+		v.Set(ptr.Elem())
+		return nil
+	}
+
+	if dataType == jsonparser.Array {
+
+		var first []byte
+		var firstType jsonparser.ValueType
+
+		_, err := jsonparser.ArrayEach(raw, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+			if first == nil {
+				first, firstType = value, dataType
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		if first == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+
+		return decodeValue(first, firstType, v)
+	}
+
+	return decodeScalar(raw, dataType, v)
+
+}
+
+func decodeSlice(raw []byte, dataType jsonparser.ValueType, v reflect.Value) error {
+
+	if dataType != jsonparser.Array {
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := decodeValue(raw, dataType, elem); err != nil {
+			return err
+		}
+
+		v.Set(reflect.Append(reflect.MakeSlice(v.Type(), 0, 1), elem))
+		return nil
+	}
+
+	slice := reflect.MakeSlice(v.Type(), 0, 0)
+	var decodeError error
+
+	_, err := jsonparser.ArrayEach(raw, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+		if decodeError != nil {
+			return
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if decodeError = decodeValue(value, dataType, elem); decodeError == nil {
+			slice = reflect.Append(slice, elem)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if decodeError != nil {
+		return decodeError
+	}
+
+	v.Set(slice)
+
+	return nil
+
+}
+
+func decodeScalar(raw []byte, dataType jsonparser.ValueType, v reflect.Value) error {
+
+	if v.Type() == timeType {
+
+		value, err := jsonparser.ParseString(raw)
+		if err != nil {
+			return err
+		}
+
+		date, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(date))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+
+		if dataType != jsonparser.String {
+			v.SetString(string(raw))
+			return nil
+		}
+
+		value, err := jsonparser.ParseString(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetString(value)
+
+	case reflect.Bool:
+
+		value, err := jsonparser.ParseBoolean(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(value)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		value, err := jsonparser.ParseInt(raw)
+		if err != nil {
+			return err
+		}
+
+		if v.OverflowInt(value) {
+			return fmt.Errorf("value %d overflows %s", value, v.Type())
+		}
+
+		v.SetInt(value)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+		value, err := jsonparser.ParseInt(raw)
+		if err != nil {
+			return err
+		}
+
+		if value < 0 || v.OverflowUint(uint64(value)) {
+			return fmt.Errorf("value %d overflows %s", value, v.Type())
+		}
+
+		v.SetUint(uint64(value))
+
+	case reflect.Float32, reflect.Float64:
+
+		value, err := jsonparser.ParseFloat(raw)
+		if err != nil {
+			return err
+		}
+
+		if v.OverflowFloat(value) {
+			return fmt.Errorf("value %g overflows %s", value, v.Type())
+		}
+
+		v.SetFloat(value)
+
+	case reflect.Struct:
+
+		if dataType != jsonparser.Object {
+			return fmt.Errorf("cannot decode %s into %s", dataType, v.Type())
+		}
+
+		return decodeDocument(raw, v)
+
+	default:
+
+		return fmt.Errorf("cannot decode into %s", v.Type())
+	}
+
+	return nil
+
+}
+
+// typedDocumentParser - decodes the response documents into dst
+type typedDocumentParser struct {
+	dst interface{}
+}
+
+// Parse - decodes the documents into dst and returns the slice
+func (p *typedDocumentParser) Parse(raw []byte) (interface{}, error) {
+
+	documents, _, _, err := jsonparser.Get(raw, rawResponse, rawDocs)
+	if err == jsonparser.KeyPathNotFoundError {
+		documents = []byte("[]")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := UnmarshalDocuments(documents, p.dst); err != nil {
+		return nil, err
+	}
+
+	return reflect.ValueOf(p.dst).Elem().Interface(), nil
+
+}
+
+// SearchInto - same as Search but the documents are decoded into dst, a pointer to a slice of structs
+// mapped with `solr` tags, the Response.Docs also holds the decoded slice
+func (s *Instance) SearchInto(params *SearchParams, instanceName string, dst interface{}) (*Response, error) {
+
+	if _, err := documentSlice(dst); err != nil {
+		return nil, err
+	}
+
+	return s.search(params, instanceName, &typedDocumentParser{dst: dst})
+
+}
@@ -0,0 +1,274 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AddOptions - options of an add command
+type AddOptions struct {
+	CommitWithin time.Duration //CommitWithin - commits the document within this time
+	NoOverwrite  bool          //NoOverwrite - does not check for an existing document with the same id (overwrite=false)
+}
+
+// DeleteOptions - options of a delete command
+type DeleteOptions struct {
+	CommitWithin time.Duration //CommitWithin - commits the delete within this time
+	Version      int64         //Version - the expected _version_ of the document, see VersionMustExist
+	Route        string        //Route - the _route_ of the document in collections using the implicit router
+}
+
+// UpdateRequest - a list of JSON update commands sent in a single request, for more information visit https://lucene.apache.org/solr/guide/7_4/uploading-data-with-index-handlers.html#sending-json-update-commands
+type UpdateRequest struct {
+	commands  []updateCommand
+	hasCommit bool
+}
+
+type updateCommand struct {
+	name string
+	body interface{}
+}
+
+// NewUpdateRequest - creates an empty UpdateRequest
+func NewUpdateRequest() *UpdateRequest {
+
+	return &UpdateRequest{}
+
+}
+
+// Add - adds a document, it is serialized with encoding/json and does not use the DocumentWriter
+func (r *UpdateRequest) Add(document interface{}) *UpdateRequest {
+
+	return r.AddWithOptions(document, nil)
+
+}
+
+// AddWithOptions - adds a document with the options
+func (r *UpdateRequest) AddWithOptions(document interface{}, options *AddOptions) *UpdateRequest {
+
+	body := map[string]interface{}{
+		rawDoc: document,
+	}
+
+	if options != nil {
+
+		if options.CommitWithin > 0 {
+			body[rawCommitWithin] = int64(options.CommitWithin / time.Millisecond)
+		}
+
+		if options.NoOverwrite {
+			body[rawOverwrite] = false
+		}
+	}
+
+	r.commands = append(r.commands, updateCommand{name: rawAdd, body: body})
+
+	return r
+
+}
+
+// DeleteByID - deletes the documents by id, options can be nil
+func (r *UpdateRequest) DeleteByID(options *DeleteOptions, ids ...string) *UpdateRequest {
+
+	for i := 0; i < len(ids); i++ {
+
+		body := options.body()
+		body[rawID] = ids[i]
+
+		r.commands = append(r.commands, updateCommand{name: rawDelete, body: body})
+	}
+
+	return r
+
+}
+
+// DeleteByQuery - deletes the documents matching the query, options can be nil
+func (r *UpdateRequest) DeleteByQuery(query string, options *DeleteOptions) *UpdateRequest {
+
+	body := options.body()
+	body[rawQuery] = query
+
+	r.commands = append(r.commands, updateCommand{name: rawDelete, body: body})
+
+	return r
+
+}
+
+// Commit - commits the previous commands, the CommitWithin and CommitNone modes are ignored
+func (r *UpdateRequest) Commit(options *CommitOptions) *UpdateRequest {
+
+	if options == nil {
+		options = &CommitOptions{Mode: CommitHard}
+	}
+
+	body := map[string]interface{}{}
+
+	switch options.Mode {
+	case CommitHard:
+
+		if options.NoOpenSearcher {
+			body[rawOpenSearcher] = false
+		}
+
+		if options.ExpungeDeletes {
+			body[rawExpungeDeletes] = true
+		}
+
+	case CommitSoft:
+
+		body[rawSoftCommit] = true
+
+	default:
+
+		return r
+	}
+
+	if options.NoWaitSearcher {
+		body[rawWaitSearcher] = false
+	}
+
+	r.commands = append(r.commands, updateCommand{name: rawCommit, body: body})
+	r.hasCommit = true
+
+	return r
+
+}
+
+// Optimize - merges the index down to maxSegments segments, zero uses the solr default of one segment
+func (r *UpdateRequest) Optimize(maxSegments int) *UpdateRequest {
+
+	body := map[string]interface{}{}
+
+	if maxSegments > 0 {
+		body[rawMaxSegments] = maxSegments
+	}
+
+	r.commands = append(r.commands, updateCommand{name: rawOptimize, body: body})
+	r.hasCommit = true
+
+	return r
+
+}
+
+// Len - the number of commands
+func (r *UpdateRequest) Len() int {
+
+	return len(r.commands)
+
+}
+
+// MarshalJSON - renders the commands as a JSON object, repeating the keys when needed
+func (r *UpdateRequest) MarshalJSON() ([]byte, error) {
+
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('{')
+
+	for i := 0; i < len(r.commands); i++ {
+
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := json.Marshal(r.commands[i].name)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := json.Marshal(r.commands[i].body)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(body)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+
+}
+
+func (options *DeleteOptions) body() map[string]interface{} {
+
+	body := map[string]interface{}{}
+
+	if options == nil {
+		return body
+	}
+
+	if options.CommitWithin > 0 {
+		body[rawCommitWithin] = int64(options.CommitWithin / time.Millisecond)
+	}
+
+	if options.Version != VersionAny {
+		body[rawVersion] = options.Version
+	}
+
+	if options.Route != "" {
+		body[rawRoute] = options.Route
+	}
+
+	return body
+
+}
+
+// SendUpdateRequest - posts the update commands, the Instance commit options are used when the request has no commit
+func (s *Instance) SendUpdateRequest(instanceName string, request *UpdateRequest) error {
+
+	if request == nil || request.Len() == 0 {
+		return fmt.Errorf("update request cannot be empty")
+	}
+
+	commit := s.commitOptions
+	if request.hasCommit {
+		commit = &CommitOptions{Mode: CommitNone}
+	}
+
+	payload, err := request.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	return s.postUpdate(s.updateURL(instanceName, stringUpdate, nil, commit), contentType, payload)
+
+}
+
+// DeleteByID - deletes the documents by id
+func (s *Instance) DeleteByID(instanceName string, ids ...string) error {
+
+	return s.DeleteByIDWithOptions(instanceName, nil, ids...)
+
+}
+
+// DeleteByIDWithOptions - deletes the documents by id with the options
+func (s *Instance) DeleteByIDWithOptions(instanceName string, options *DeleteOptions, ids ...string) error {
+
+	if len(ids) == 0 {
+		return fmt.Errorf("at least one id must be defined")
+	}
+
+	return s.SendUpdateRequest(instanceName, NewUpdateRequest().DeleteByID(options, ids...))
+
+}
+
+// DeleteByQuery - deletes the documents matching the query
+func (s *Instance) DeleteByQuery(instanceName, query string) error {
+
+	return s.DeleteByQueryWithOptions(instanceName, query, nil)
+
+}
+
+// DeleteByQueryWithOptions - deletes the documents matching the query with the options
+func (s *Instance) DeleteByQueryWithOptions(instanceName, query string, options *DeleteOptions) error {
+
+	if query == "" {
+		return fmt.Errorf("query cannot be empty")
+	}
+
+	return s.SendUpdateRequest(instanceName, NewUpdateRequest().DeleteByQuery(query, options))
+
+}
@@ -0,0 +1,92 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/buger/jsonparser"
+)
+
+// The _version_ sentinels for optimistic concurrency, any other positive value must match
+// the indexed document, for more information visit https://lucene.apache.org/solr/guide/7_4/updating-parts-of-documents.html#optimistic-concurrency
+const (
+	// VersionAny - no version check
+	VersionAny int64 = 0
+	// VersionMustExist - the document must exist
+	VersionMustExist int64 = 1
+	// VersionMustNotExist - the document must not exist
+	VersionMustNotExist int64 = -1
+)
+
+// ModifyFunc - changes a document read by ReadModifyWrite, the document is nil when it does not exist
+// and returning a nil document skips the write
+type ModifyFunc func(document DocumentRaw) (DocumentRaw, error)
+
+// ReadModifyWrite - reads the document with real-time get, modifies it and writes it back expecting the same
+// _version_, retrying up to maxRetries times on version conflicts
+func (s *Instance) ReadModifyWrite(instanceName, id string, maxRetries int, modify ModifyFunc) error {
+
+	var err error
+
+	for i := 0; i <= maxRetries; i++ {
+
+		err = s.readModifyWrite(instanceName, id, modify)
+
+		var conflict *VersionConflictError
+		if !errors.As(err, &conflict) {
+			return err
+		}
+	}
+
+	return err
+
+}
+
+func (s *Instance) readModifyWrite(instanceName, id string, modify ModifyFunc) error {
+
+	raw, err := s.httpGet(s.getURL(instanceName, nil, []string{id}))
+	if err != nil {
+		return err
+	}
+
+	var document DocumentRaw
+	version := VersionMustNotExist
+
+	value, dataType, _, err := jsonparser.Get(raw, rawDoc)
+	if err != nil {
+		return fmt.Errorf("error parsing doc: %v", err.Error())
+	}
+
+	if dataType != jsonparser.Null {
+
+		// the numbers are kept as json.Number to not lose the precision of longs
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		decoder.UseNumber()
+
+		if err = decoder.Decode(&document); err != nil {
+			return err
+		}
+
+		if version, err = jsonparser.GetInt(value, rawVersion); err != nil {
+			return fmt.Errorf("error parsing _version_: %v", err.Error())
+		}
+	}
+
+	document, err = modify(document)
+	if err != nil || document == nil {
+		return err
+	}
+
+	document[rawID] = id
+	document[rawVersion] = version
+
+	payload, err := json.Marshal([]DocumentRaw{document})
+	if err != nil {
+		return err
+	}
+
+	return s.postUpdate(s.updateURL(instanceName, stringUpdate, nil, s.commitOptions), contentType, payload)
+
+}
@@ -0,0 +1,61 @@
+package solr
+
+import (
+	"github.com/buger/jsonparser"
+)
+
+// FieldVisitor - receives each field of each document, document is the index of the document in the response,
+// the name and value are slices of the response body valid only during the call, string values are not
+// unescaped (use jsonparser.ParseString when needed) and arrays and objects are given as raw JSON
+type FieldVisitor func(document int, name []byte, dataType jsonparser.ValueType, value []byte) error
+
+// VisitorDocumentParser - a DocumentParser walking response.docs with jsonparser, without building intermediate
+// maps, calling the Visitor for each field, Response.Docs holds the number of visited documents as an int
+type VisitorDocumentParser struct {
+	Visitor FieldVisitor
+}
+
+// Parse - visits the fields of the response documents
+func (p *VisitorDocumentParser) Parse(raw []byte) (interface{}, error) {
+
+	documents, dataType, _, err := jsonparser.Get(raw, rawResponse, rawDocs)
+	if err == jsonparser.KeyPathNotFoundError || dataType == jsonparser.Null {
+		return 0, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := VisitDocuments(documents, p.Visitor)
+
+	return count, err
+
+}
+
+// VisitDocuments - calls the visitor for each field of a JSON array of documents, returning the number of documents
+func VisitDocuments(documents []byte, visitor FieldVisitor) (int, error) {
+
+	var index int
+	var visitError error
+
+	visitField := func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		return visitor(index, key, dataType, value)
+	}
+
+	_, err := jsonparser.ArrayEach(documents, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+
+		if visitError != nil {
+			return
+		}
+
+		if visitError = jsonparser.ObjectEach(value, visitField); visitError == nil {
+			index++
+		}
+	})
+	if err != nil {
+		return index, err
+	}
+
+	return index, visitError
+
+}